@@ -0,0 +1,486 @@
+package main
+
+import (
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// step is a field on the path from the receiver to a resolved field.
+type step struct {
+	name       string
+	isPtr      bool
+	elem       types.Type // the type pointed by the embedded pointer
+	unexported bool       // the embedded pointer to an unexported struct, which the decoder cannot set
+}
+
+// field is a struct field resolved for encoding or decoding.
+type field struct {
+	path       []step
+	key        string
+	typ        types.Type
+	tag        *runtime.StructTag
+	rawTag     string
+	structName string
+}
+
+func (f *field) selector() string {
+	names := make([]string, len(f.path))
+	for i, s := range f.path {
+		names[i] = s.name
+	}
+	return "v." + strings.Join(names, ".")
+}
+
+func (f *field) name() string {
+	return f.path[len(f.path)-1].name
+}
+
+// structField is a field of a struct type with its parsed tag.
+type structField struct {
+	v      *types.Var
+	rawTag string
+	tag    *runtime.StructTag
+}
+
+// structFields returns the fields of st that are not ignored, as runtime.IsIgnoredStructField does.
+func structFields(st *types.Struct) ([]*structField, runtime.StructTags) {
+	var (
+		fields []*structField
+		tags   runtime.StructTags
+	)
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		rawTag := st.Tag(i)
+		if !v.Exported() {
+			if !v.Anonymous() || structType(v.Type(), true) == nil {
+				continue
+			}
+		}
+		sf := reflect.StructField{Name: v.Name(), Tag: reflect.StructTag(rawTag), Anonymous: v.Anonymous()}
+		if sf.Tag.Get("json") == "-" {
+			continue
+		}
+		tag := runtime.StructTagFromField(sf)
+		fields = append(fields, &structField{v: v, rawTag: rawTag, tag: tag})
+		tags = append(tags, tag)
+	}
+	return fields, tags
+}
+
+// structType returns the struct type of typ, or of the type pointed by typ if allowPtr is true.
+func structType(typ types.Type, allowPtr bool) *types.Struct {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok && allowPtr {
+		typ = ptr.Elem()
+	}
+	st, _ := typ.Underlying().(*types.Struct)
+	return st
+}
+
+func hasMethod(typ types.Type, names ...string) bool {
+	ms := types.NewMethodSet(typ)
+	for i := 0; i < ms.Len(); i++ {
+		name := ms.At(i).Obj().Name()
+		for _, n := range names {
+			if name == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isNamed(typ types.Type, pkgPaths []string, name string) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Name() != name {
+		return false
+	}
+	for _, path := range pkgPaths {
+		if named.Obj().Pkg().Path() == path {
+			return true
+		}
+	}
+	return false
+}
+
+func isTimeType(typ types.Type) bool {
+	return isNamed(typ, []string{"time"}, "Time")
+}
+
+func isMarshalerType(typ types.Type) bool {
+	return hasMethod(typ, "MarshalJSON", "MarshalText")
+}
+
+func isUnmarshalerType(typ types.Type) bool {
+	return hasMethod(types.NewPointer(typ), "UnmarshalJSON", "UnmarshalText")
+}
+
+// encodeStruct is the result of Compiler.structCode for a struct type.
+type encodeStruct struct {
+	name        string
+	fields      []*encodeField
+	isRecursive bool
+}
+
+// encodeField is StructFieldCode of the encoder.
+type encodeField struct {
+	sf          *structField
+	key         string
+	isTaggedKey bool
+	isAnonymous bool
+	isPtr       bool
+	embedded    *encodeStruct
+}
+
+type encodeResolver struct {
+	stack []types.Type
+}
+
+// resolveEncodeFields returns the fields of typ in the order of encoding, resolved by the rules of Compiler.structCode:
+// the fields of the embedded structs are removed if their keys are the keys of the outer struct,
+// and the fields of the same key are removed unless only one of them has the key by the tag.
+func resolveEncodeFields(typ types.Type) []*field {
+	r := &encodeResolver{}
+	code := r.structCode(typ)
+	var fields []*field
+	appendEncodeFields(&fields, code, nil)
+	return fields
+}
+
+func appendEncodeFields(fields *[]*field, code *encodeStruct, path []step) {
+	for _, f := range code.fields {
+		p := append(append([]step{}, path...), step{name: f.sf.v.Name(), isPtr: f.isPtr})
+		if f.embedded != nil && !f.embedded.isRecursive {
+			appendEncodeFields(fields, f.embedded, p)
+			continue
+		}
+		*fields = append(*fields, &field{
+			path:       p,
+			key:        f.key,
+			typ:        f.sf.v.Type(),
+			tag:        f.sf.tag,
+			rawTag:     f.sf.rawTag,
+			structName: code.name,
+		})
+	}
+}
+
+func typeName(typ types.Type) string {
+	if named, ok := typ.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return ""
+}
+
+func (r *encodeResolver) structCode(typ types.Type) *encodeStruct {
+	for _, t := range r.stack {
+		if types.Identical(t, typ) {
+			return &encodeStruct{name: typeName(typ), isRecursive: true}
+		}
+	}
+	r.stack = append(r.stack, typ)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	code := &encodeStruct{name: typeName(typ)}
+	sfs, tags := structFields(structType(typ, false))
+	fields := make([]*encodeField, 0, len(sfs))
+	for _, sf := range sfs {
+		f := &encodeField{
+			sf:          sf,
+			key:         sf.tag.Key,
+			isTaggedKey: sf.tag.IsTaggedKey,
+			isAnonymous: sf.v.Anonymous() && !sf.tag.IsTaggedKey,
+		}
+		if f.isAnonymous {
+			f.embedded, f.isPtr = r.anonymousStruct(sf.v.Type())
+			if f.embedded != nil && !f.embedded.isRecursive {
+				f.embedded.removeFieldsByTags(tags)
+			}
+		}
+		fields = append(fields, f)
+	}
+	fieldMap := getEncodeFieldMap(fields)
+	duplicated := getDuplicatedFieldMap(fieldMap)
+	code.fields = filteredDuplicatedFields(fields, duplicated)
+	return code
+}
+
+// anonymousStruct returns the struct of the embedded field of typ, unless it is encoded by the marshaler methods.
+func (r *encodeResolver) anonymousStruct(typ types.Type) (*encodeStruct, bool) {
+	isPtr := false
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		if isMarshalerType(typ) {
+			return nil, false
+		}
+		typ = ptr.Elem()
+		isPtr = true
+	}
+	if structType(typ, false) == nil || isTimeType(typ) || isMarshalerType(typ) {
+		return nil, false
+	}
+	return r.structCode(typ), isPtr
+}
+
+func (c *encodeStruct) removeFieldsByTags(tags runtime.StructTags) {
+	fields := make([]*encodeField, 0, len(c.fields))
+	for _, f := range c.fields {
+		if f.isAnonymous && f.embedded != nil && !f.embedded.isRecursive {
+			f.embedded.removeFieldsByTags(tags)
+			if len(f.embedded.fields) > 0 {
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if tags.ExistsKey(f.key) {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	c.fields = fields
+}
+
+func getEncodeFieldMap(fields []*encodeField) map[string][]*encodeField {
+	fieldMap := map[string][]*encodeField{}
+	for _, f := range fields {
+		if f.isAnonymous {
+			for k, v := range getAnonymousFieldMap(f) {
+				fieldMap[k] = append(fieldMap[k], v...)
+			}
+			continue
+		}
+		fieldMap[f.key] = append(fieldMap[f.key], f)
+	}
+	return fieldMap
+}
+
+func getAnonymousFieldMap(f *encodeField) map[string][]*encodeField {
+	fieldMap := map[string][]*encodeField{}
+	if f.embedded == nil || f.embedded.isRecursive {
+		fieldMap[f.key] = append(fieldMap[f.key], f)
+		return fieldMap
+	}
+	for k, v := range getFieldMapFromAnonymousParent(f.embedded.fields) {
+		fieldMap[k] = append(fieldMap[k], v...)
+	}
+	return fieldMap
+}
+
+func getFieldMapFromAnonymousParent(fields []*encodeField) map[string][]*encodeField {
+	fieldMap := map[string][]*encodeField{}
+	for _, f := range fields {
+		if f.isAnonymous {
+			for k, v := range getAnonymousFieldMap(f) {
+				// the tagged keys are not handled when embedding more than once
+				for _, vv := range v {
+					vv.isTaggedKey = false
+				}
+				fieldMap[k] = append(fieldMap[k], v...)
+			}
+			continue
+		}
+		fieldMap[f.key] = append(fieldMap[f.key], f)
+	}
+	return fieldMap
+}
+
+func getDuplicatedFieldMap(fieldMap map[string][]*encodeField) map[*encodeField]struct{} {
+	duplicated := map[*encodeField]struct{}{}
+	for _, fields := range fieldMap {
+		if len(fields) == 1 {
+			continue
+		}
+		tagged := 0
+		for _, f := range fields {
+			if f.isTaggedKey {
+				tagged++
+			}
+		}
+		for _, f := range fields {
+			if tagged == 1 && f.isTaggedKey {
+				continue
+			}
+			duplicated[f] = struct{}{}
+		}
+	}
+	return duplicated
+}
+
+func filteredDuplicatedFields(fields []*encodeField, duplicated map[*encodeField]struct{}) []*encodeField {
+	filtered := make([]*encodeField, 0, len(fields))
+	for _, f := range fields {
+		if f.isAnonymous && f.embedded != nil && !f.embedded.isRecursive {
+			f.embedded.fields = filteredDuplicatedFields(f.embedded.fields, duplicated)
+			if len(f.embedded.fields) > 0 {
+				filtered = append(filtered, f)
+			}
+			continue
+		}
+		if _, exists := duplicated[f]; exists {
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
+
+// decodeStruct is the result of compileStruct for a struct type.
+type decodeStruct struct {
+	typ      types.Type
+	fieldMap map[string]*decodeField
+}
+
+// decodeField is structFieldSet of the decoder.
+type decodeField struct {
+	*field
+	isTaggedKey bool
+}
+
+type decodeResolver struct {
+	inProgress []*decodeStruct
+}
+
+// resolveDecodeFields returns the map from the keys, including the lower case aliases, to the fields of typ,
+// resolved by the rules of compileStruct: the fields are registered in the order of the struct,
+// and the keys of the embedded structs conflict unless only one of them has the key by the tag.
+func resolveDecodeFields(typ types.Type) map[string]*decodeField {
+	r := &decodeResolver{}
+	return r.compileStruct(typ).fieldMap
+}
+
+func (r *decodeResolver) compileStruct(typ types.Type) *decodeStruct {
+	for _, dec := range r.inProgress {
+		if types.Identical(dec.typ, typ) {
+			return dec
+		}
+	}
+	dec := &decodeStruct{typ: typ, fieldMap: map[string]*decodeField{}}
+	r.inProgress = append(r.inProgress, dec)
+	defer func() { r.inProgress = r.inProgress[:len(r.inProgress)-1] }()
+
+	fieldMap := dec.fieldMap
+	conflicted := map[string]struct{}{}
+	structName := typeName(typ)
+	sfs, _ := structFields(structType(typ, false))
+	for _, sf := range sfs {
+		ftyp := sf.v.Type()
+		if sf.v.Anonymous() && !sf.tag.IsTaggedKey {
+			s := step{name: sf.v.Name()}
+			if ptr, ok := ftyp.Underlying().(*types.Pointer); ok {
+				s.isPtr = true
+				s.elem = ptr.Elem()
+				s.unexported = !sf.v.Exported()
+				ftyp = ptr.Elem()
+			}
+			if structType(ftyp, false) == nil || isTimeType(ftyp) || isUnmarshalerType(ftyp) {
+				// decoded by the other decoders, which have no fields to promote
+				continue
+			}
+			if types.Identical(ftyp, typ) {
+				// recursive definition
+				continue
+			}
+			sub := r.compileStruct(ftyp)
+			removeConflictFields(fieldMap, conflicted, sub, s)
+			continue
+		}
+		f := &decodeField{
+			field: &field{
+				path:       []step{{name: sf.v.Name()}},
+				key:        sf.tag.Key,
+				typ:        ftyp,
+				tag:        sf.tag,
+				rawTag:     sf.rawTag,
+				structName: structName,
+			},
+			isTaggedKey: sf.tag.IsTaggedKey,
+		}
+		fieldMap[f.key] = f
+		lower := strings.ToLower(f.key)
+		if _, exists := fieldMap[lower]; !exists {
+			fieldMap[lower] = f
+		}
+	}
+	return dec
+}
+
+func removeConflictFields(fieldMap map[string]*decodeField, conflicted map[string]struct{}, sub *decodeStruct, s step) {
+	keys := make([]string, 0, len(sub.fieldMap))
+	for k := range sub.fieldMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := sub.fieldMap[k]
+		if v.key != k {
+			// the lower case alias is added with its key
+			continue
+		}
+		if _, exists := conflicted[k]; exists {
+			continue
+		}
+		promoted := &decodeField{
+			field: &field{
+				path:       append([]step{s}, v.path...),
+				key:        k,
+				typ:        v.typ,
+				tag:        v.tag,
+				rawTag:     v.rawTag,
+				structName: v.structName,
+			},
+			isTaggedKey: v.isTaggedKey,
+		}
+		set, exists := fieldMap[k]
+		switch {
+		case !exists, !set.isTaggedKey && v.isTaggedKey:
+			fieldMap[k] = promoted
+			lower := strings.ToLower(k)
+			if _, exists := fieldMap[lower]; !exists {
+				fieldMap[lower] = promoted
+			}
+		case set.isTaggedKey != v.isTaggedKey:
+			// the tagged key of the outer struct wins
+		default:
+			delete(fieldMap, k)
+			delete(fieldMap, strings.ToLower(k))
+			conflicted[k] = struct{}{}
+			conflicted[strings.ToLower(k)] = struct{}{}
+		}
+	}
+}
+
+const (
+	allowOptimizeMaxKeyLen   = 64
+	allowOptimizeMaxFieldLen = 16
+)
+
+// isCaseInsensitive reports whether the decoder matches the keys of fieldMap by lower case, as structDecoder.tryOptimize does.
+// Otherwise the keys are matched exactly with the lower case aliases in fieldMap.
+func isCaseInsensitive(fieldMap map[string]*decodeField) bool {
+	lowerMap := map[string]*decodeField{}
+	conflicted := map[string]struct{}{}
+	for k, v := range fieldMap {
+		key := strings.ToLower(k)
+		if key != k {
+			if _, exists := conflicted[key]; exists {
+				return false
+			}
+			conflicted[key] = struct{}{}
+		}
+		if f, exists := lowerMap[key]; exists && f != v {
+			return false
+		}
+		lowerMap[key] = v
+	}
+	if len(lowerMap) > allowOptimizeMaxFieldLen {
+		return false
+	}
+	for key := range lowerMap {
+		if len(key) > allowOptimizeMaxKeyLen {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,745 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/goccy/go-json/internal/runtime"
+	"github.com/goccy/go-json/jsongen"
+)
+
+var fileTmpl = template.Must(template.New("").Parse(`// Code generated by jsongen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	{{ . }}
+{{- end }}
+)
+{{ range .Types }}
+// MarshalJSON encodes v with the fields resolved as the encoder of go-json does for {{ .Name }}.
+// The options of MarshalWithOption are not applied.
+func (v {{ .Name }}) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(func(b []byte) ([]byte, error) {
+		return {{ .AppendFunc }}(b, &v)
+	})
+}
+
+// UnmarshalJSON decodes data into v with the fields resolved as the decoder of go-json does for {{ .Name }}.
+// The options of UnmarshalWithOption are not applied.
+func (v *{{ .Name }}) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	if err := {{ .DecodeFunc }}(&l, v); err != nil {
+		return err
+	}
+	return l.End()
+}
+{{ end }}
+{{- range .Structs }}
+func {{ .AppendFunc }}(b []byte, v *{{ .Name }}) ([]byte, error) {
+{{- if .NeedsErr }}
+	var err error
+{{- end }}
+	b = append(b, '{')
+{{- range .Encode }}
+{{ . }}
+{{- end }}
+	return jsongen.EndObject(b), nil
+}
+
+func {{ .DecodeFunc }}(l *jsongen.Lexer, v *{{ .Name }}) error {
+	if l.Null() {
+		return nil
+	}
+	more, err := l.BeginObject()
+	if err != nil {
+		return err
+	}
+	for more {
+{{- if .Decode }}
+		key, err := l.Key()
+		if err != nil {
+			return err
+		}
+		var field int
+		switch string(key) {
+{{- range .Keys }}
+		case {{ .Keys }}:
+			field = {{ .Field }}
+{{- end }}
+{{- if .CaseInsensitive }}
+		default:
+			switch string(jsongen.ToLowerASCII(key)) {
+{{- range .LowerKeys }}
+			case {{ .Keys }}:
+				field = {{ .Field }}
+{{- end }}
+			}
+{{- end }}
+		}
+		switch field {
+{{- range $i, $code := .Decode }}
+		case {{ $i }}:
+{{ $code }}
+{{- end }}
+		default:
+			if err := l.Skip(); err != nil {
+				return err
+			}
+		}
+{{- else }}
+		if _, err := l.Key(); err != nil {
+			return err
+		}
+		if err := l.Skip(); err != nil {
+			return err
+		}
+{{- end }}
+		if more, err = l.NextMember(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{ end -}}
+`))
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string // path => name
+	types   []*structCode
+	structs []*structCode
+	index   map[string]*structCode
+	pending []types.Type // the struct types whose functions are not generated yet
+}
+
+// structCode is the code of the functions encoding and decoding a struct type, which are called for the values of the type
+// in the other structs and by the methods of the types given by -type.
+type structCode struct {
+	Name            string
+	AppendFunc      string
+	DecodeFunc      string
+	NeedsErr        bool
+	Encode          []string
+	Keys            []keyCase
+	LowerKeys       []keyCase
+	CaseInsensitive bool
+	Decode          map[int]string
+}
+
+type keyCase struct {
+	Keys  string
+	Field int
+}
+
+// valueOption is the options of the tag applied to a value of a field, which are also applied to the elements
+// of the slices and the values pointed by the pointers as the runtime does.
+type valueOption struct {
+	quoted     bool
+	precision  int
+	format     string
+	nilAsEmpty bool
+	omitted    bool // the nil value is omitted by omitempty
+	structName string
+	fieldName  string
+}
+
+// tag returns the tag passed to jsongen.Lexer.Decode for the value, so that the runtime applies the same options.
+func (o valueOption) tag() string {
+	var opts string
+	if o.quoted {
+		opts += ",string"
+	}
+	if o.format != "" {
+		opts += ",format=" + o.format
+	}
+	if opts == "" {
+		return ""
+	}
+	return "json:" + strconv.Quote(opts)
+}
+
+func newGenerator(pkg *types.Package) *generator {
+	return &generator{
+		pkg:     pkg,
+		imports: map[string]string{"github.com/goccy/go-json/jsongen": "jsongen"},
+		index:   map[string]*structCode{},
+	}
+}
+
+func (g *generator) Package() string {
+	return g.pkg.Name()
+}
+
+func (g *generator) Imports() []string {
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, strconv.Quote(path))
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+func (g *generator) Types() []*structCode {
+	return g.types
+}
+
+func (g *generator) Structs() []*structCode {
+	return g.structs
+}
+
+// qualifier refers to the types of the other packages by their names, and imports them.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (g *generator) typeString(typ types.Type) string {
+	return types.TypeString(typ, g.qualifier)
+}
+
+func (g *generator) addType(typ types.Type) {
+	g.types = append(g.types, g.structCode(typ))
+}
+
+// generateStructs generates the functions of the struct types found in the fields until all of them are generated.
+func (g *generator) generateStructs() {
+	for len(g.pending) > 0 {
+		typ := g.pending[0]
+		g.pending = g.pending[1:]
+		code := g.index[typ.(*types.Named).Obj().Name()]
+		for _, f := range resolveEncodeFields(typ) {
+			code.Encode = append(code.Encode, g.encodeField(code, f))
+		}
+		g.addDecodeFields(code, resolveDecodeFields(typ))
+	}
+}
+
+// structCode returns the code of the struct type, and adds it to be generated if it is new.
+func (g *generator) structCode(typ types.Type) *structCode {
+	name := typ.(*types.Named).Obj().Name()
+	if code, exists := g.index[name]; exists {
+		return code
+	}
+	code := &structCode{
+		Name:       name,
+		AppendFunc: "jsongenAppend" + name,
+		DecodeFunc: "jsongenDecode" + name,
+		Decode:     map[int]string{},
+	}
+	g.index[name] = code
+	g.structs = append(g.structs, code)
+	g.pending = append(g.pending, typ)
+	return code
+}
+
+// isGeneratedStruct reports whether the functions are generated for the struct type,
+// which is a named struct type of the package without the marshaler methods.
+func (g *generator) isGeneratedStruct(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != g.pkg || named.Obj().Parent() != g.pkg.Scope() {
+		return false
+	}
+	_, ok = named.Underlying().(*types.Struct)
+	return ok
+}
+
+// isRuntimeType reports whether the values of typ are encoded and decoded by the runtime,
+// such as the maps, the interfaces, the arrays and the types with the marshaler methods.
+func (g *generator) isRuntimeType(typ types.Type) bool {
+	if isTimeType(typ) {
+		return false
+	}
+	if isNamed(typ, []string{"encoding/json", "github.com/goccy/go-json"}, "Number") {
+		return true
+	}
+	if hasMethod(types.NewPointer(typ), "MarshalJSON", "MarshalText", "UnmarshalJSON", "UnmarshalText") {
+		return true
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		kind, _ := basicKind(t)
+		return kind == ""
+	case *types.Pointer:
+		return false
+	case *types.Slice:
+		if isByteSlice(t) {
+			// the runtime encodes the named byte types in the slices as the numbers
+			basic, ok := t.Elem().(*types.Basic)
+			return !ok || basic.Kind() != types.Uint8
+		}
+		return false
+	case *types.Struct:
+		return !g.isGeneratedStruct(typ)
+	}
+	return true
+}
+
+// basicKind returns the kind of the basic type written and read in place, and the bit size for the numbers.
+func basicKind(typ types.Type) (string, int) {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return "", 0
+	}
+	switch basic.Kind() {
+	case types.String:
+		return "string", 0
+	case types.Bool:
+		return "bool", 0
+	case types.Int:
+		return "int", 0
+	case types.Int8:
+		return "int", 8
+	case types.Int16:
+		return "int", 16
+	case types.Int32:
+		return "int", 32
+	case types.Int64:
+		return "int", 64
+	case types.Uint:
+		return "uint", 0
+	case types.Uint8:
+		return "uint", 8
+	case types.Uint16:
+		return "uint", 16
+	case types.Uint32:
+		return "uint", 32
+	case types.Uint64:
+		return "uint", 64
+	case types.Float32:
+		return "float", 32
+	case types.Float64:
+		return "float", 64
+	}
+	return "", 0
+}
+
+func isByteSlice(t *types.Slice) bool {
+	basic, ok := t.Elem().Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Uint8
+}
+
+// isInlineField reports whether the options of tag are applied to the value of typ in place.
+// The other fields are passed to the runtime with the tag by jsongen.AppendField and jsongen.Lexer.Decode.
+// The nilasempty option is ignored for decoding.
+func (g *generator) isInlineField(typ types.Type, tag *runtime.StructTag, decode bool) bool {
+	if tag.IsString && !g.isInlineStringOption(typ) {
+		return false
+	}
+	if tag.Format != "" || (tag.IsNilEmpty && !decode) {
+		return g.isInlineOption(typ, tag, decode)
+	}
+	return true
+}
+
+// isInlineStringOption reports whether the string option is applied to the value of typ in place.
+// The option quotes the basic kinds and the values pointed by the pointers to them, and is ignored for time.Time,
+// the slices and the structs.
+func (g *generator) isInlineStringOption(typ types.Type) bool {
+	if isTimeType(typ) {
+		return true
+	}
+	if g.isRuntimeType(typ) {
+		return false
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return true
+	case *types.Pointer:
+		_, ok := t.Elem().Underlying().(*types.Basic)
+		return ok && !g.isRuntimeType(t.Elem())
+	}
+	return true
+}
+
+// isInlineOption reports whether the format and nilasempty options are applied to the value of typ in place,
+// that is, the options do not reach the values encoded by the runtime or the fields of the structs.
+func (g *generator) isInlineOption(typ types.Type, tag *runtime.StructTag, decode bool) bool {
+	if isTimeType(typ) {
+		return true
+	}
+	if g.isRuntimeType(typ) {
+		return false
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return true
+	case *types.Pointer:
+		return g.isInlineOption(t.Elem(), tag, decode)
+	case *types.Slice:
+		if isByteSlice(t) {
+			// the format option selects the encoding of the bytes
+			return tag.Format == ""
+		}
+		return g.isInlineOption(t.Elem(), tag, decode)
+	case *types.Struct:
+		return decode || !tag.IsNilEmpty
+	}
+	return false
+}
+
+// emptyCond returns the condition that the value of f is not empty for omitempty, or "" if the value is never omitted.
+func emptyCond(sel string, typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsString != 0:
+			return sel + ` != ""`
+		case t.Info()&types.IsBoolean != 0:
+			return sel
+		case t.Info()&types.IsNumeric != 0:
+			return sel + " != 0"
+		}
+	case *types.Pointer, *types.Map, *types.Interface, *types.Signature, *types.Chan:
+		return sel + " != nil"
+	case *types.Slice:
+		return "len(" + sel + ") != 0"
+	}
+	return ""
+}
+
+// addr returns the address of the value of expr.
+func addr(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return expr[1:]
+	}
+	return "&" + expr
+}
+
+// operand returns expr to be indexed or sliced.
+func operand(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return "(" + expr + ")"
+	}
+	return expr
+}
+
+func appendErr(call string) string {
+	return fmt.Sprintf("if b, err = %s; err != nil {\n\treturn nil, err\n}", call)
+}
+
+func returnErr(call string) string {
+	return fmt.Sprintf("if err := %s; err != nil {\n\treturn err\n}", call)
+}
+
+func (g *generator) encodeField(code *structCode, f *field) string {
+	var conds []string
+	sels := make([]string, 0, len(f.path))
+	for i, s := range f.path {
+		sels = append(sels, s.name)
+		if s.isPtr && i < len(f.path)-1 {
+			conds = append(conds, "v."+strings.Join(sels, ".")+" != nil")
+		}
+	}
+	sel := f.selector()
+	omitted := false
+	if f.tag.IsOmitEmpty {
+		if cond := emptyCond(sel, f.typ); cond != "" {
+			conds = append(conds, cond)
+			omitted = true
+		}
+	}
+	key := jsongen.AppendString(nil, f.key)
+	var body []string
+	body = append(body, fmt.Sprintf("b = append(b, %s...)", strconv.Quote(string(key)+":")))
+	if g.isInlineField(f.typ, f.tag, false) {
+		opt := valueOption{
+			quoted:     f.tag.IsString,
+			precision:  f.tag.Precision,
+			format:     f.tag.Format,
+			nilAsEmpty: f.tag.IsNilEmpty,
+			omitted:    omitted,
+		}
+		body = append(body, g.encodeValue(code, sel, f.typ, opt, 0)...)
+	} else {
+		code.NeedsErr = true
+		body = append(body, appendErr(fmt.Sprintf("jsongen.AppendField(b, &%s, %s)", sel, strconv.Quote(f.rawTag))))
+	}
+	body = append(body, "b = append(b, ',')")
+	stmt := strings.Join(body, "\n")
+	if len(conds) > 0 {
+		stmt = fmt.Sprintf("if %s {\n%s\n}", strings.Join(conds, " && "), stmt)
+	}
+	return stmt
+}
+
+// encodeValue returns the statements appending the value of expr to b.
+func (g *generator) encodeValue(code *structCode, expr string, typ types.Type, opt valueOption, depth int) []string {
+	switch {
+	case isTimeType(typ):
+		code.NeedsErr = true
+		return []string{appendErr(fmt.Sprintf("jsongen.AppendTime(b, %s, %s)", expr, strconv.Quote(opt.format)))}
+	case g.isRuntimeType(typ):
+		code.NeedsErr = true
+		if !isMarshalerType(typ) && isMarshalerType(types.NewPointer(typ)) {
+			// the runtime calls the methods of the pointer receivers for the addressable values
+			return []string{appendErr(fmt.Sprintf("jsongen.AppendValue(b, %s)", addr(expr)))}
+		}
+		return []string{appendErr(fmt.Sprintf("jsongen.AppendValue(b, %s)", expr))}
+	}
+	if kind, bits := basicKind(typ); kind != "" {
+		return g.encodeBasic(code, expr, kind, bits, opt)
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Pointer:
+		var body []string
+		if g.isRuntimeType(t.Elem()) {
+			// the runtime encodes the pointer to the big numbers and the types with the pointer receivers
+			code.NeedsErr = true
+			body = []string{appendErr(fmt.Sprintf("jsongen.AppendValue(b, %s)", expr))}
+		} else {
+			elemOpt := opt
+			elemOpt.omitted = false
+			body = g.encodeValue(code, "*"+expr, t.Elem(), elemOpt, depth)
+		}
+		if opt.omitted {
+			return body
+		}
+		stmts := []string{fmt.Sprintf("if %s == nil {\n\tb = append(b, \"null\"...)\n} else {", expr)}
+		stmts = append(stmts, body...)
+		return append(stmts, "}")
+	case *types.Slice:
+		empty := `"null"`
+		if isByteSlice(t) {
+			if opt.nilAsEmpty {
+				empty = `"\"\""`
+			}
+			if opt.omitted {
+				return []string{fmt.Sprintf("b = jsongen.AppendBytes(b, %s)", expr)}
+			}
+			return []string{fmt.Sprintf("if %s == nil {\n\tb = append(b, %s...)\n} else {\n\tb = jsongen.AppendBytes(b, %s)\n}", expr, empty, expr)}
+		}
+		if opt.nilAsEmpty {
+			empty = `"[]"`
+		}
+		i := fmt.Sprintf("i%d", depth)
+		elemOpt := opt
+		elemOpt.quoted = false
+		elemOpt.precision = -1
+		elemOpt.omitted = false
+		stmts := []string{
+			"b = append(b, '[')",
+			fmt.Sprintf("for %s := range %s {", i, expr),
+			fmt.Sprintf("if %s > 0 {\n\tb = append(b, ',')\n}", i),
+		}
+		stmts = append(stmts, g.encodeValue(code, fmt.Sprintf("%s[%s]", operand(expr), i), t.Elem(), elemOpt, depth+1)...)
+		stmts = append(stmts, "}", "b = append(b, ']')")
+		if opt.omitted {
+			return stmts
+		}
+		return append(append([]string{fmt.Sprintf("if %s == nil {\n\tb = append(b, %s...)\n} else {", expr, empty)}, stmts...), "}")
+	case *types.Struct:
+		code.NeedsErr = true
+		return []string{appendErr(fmt.Sprintf("%s(b, %s)", g.structCode(typ).AppendFunc, addr(expr)))}
+	}
+	panic(fmt.Sprintf("jsongen: unexpected type %s", typ))
+}
+
+func (g *generator) encodeBasic(code *structCode, expr, kind string, bits int, opt valueOption) []string {
+	quoted := opt.quoted && kind != "string"
+	var stmts []string
+	if quoted {
+		stmts = append(stmts, "b = append(b, '\"')")
+	}
+	switch kind {
+	case "string":
+		if opt.quoted {
+			stmts = append(stmts, fmt.Sprintf("b = jsongen.AppendQuotedString(b, string(%s))", expr))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("b = jsongen.AppendString(b, string(%s))", expr))
+		}
+	case "bool":
+		stmts = append(stmts, fmt.Sprintf("b = jsongen.AppendBool(b, bool(%s))", expr))
+	case "int":
+		stmts = append(stmts, fmt.Sprintf("b = jsongen.AppendInt(b, int64(%s))", expr))
+	case "uint":
+		stmts = append(stmts, fmt.Sprintf("b = jsongen.AppendUint(b, uint64(%s))", expr))
+	case "float":
+		code.NeedsErr = true
+		if opt.precision >= 0 {
+			stmts = append(stmts, appendErr(fmt.Sprintf("jsongen.AppendFloat%dPrecision(b, float%d(%s), %d)", bits, bits, expr, opt.precision)))
+		} else {
+			stmts = append(stmts, appendErr(fmt.Sprintf("jsongen.AppendFloat%d(b, float%d(%s))", bits, bits, expr)))
+		}
+	}
+	if quoted {
+		stmts = append(stmts, "b = append(b, '\"')")
+	}
+	return stmts
+}
+
+func (g *generator) addDecodeFields(code *structCode, fieldMap map[string]*decodeField) {
+	keys := make([]string, 0, len(fieldMap))
+	for k := range fieldMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	index := map[*decodeField]int{}
+	fieldKeys := map[int][]string{}
+	lowerKeys := map[string]int{}
+	for _, k := range keys {
+		f := fieldMap[k]
+		i, exists := index[f]
+		if !exists {
+			i = len(index) + 1
+			index[f] = i
+			code.Decode[i] = g.decodeField(f.field)
+		}
+		fieldKeys[i] = append(fieldKeys[i], strconv.Quote(k))
+		lowerKeys[strings.ToLower(k)] = i
+	}
+	for i := 1; i <= len(index); i++ {
+		code.Keys = append(code.Keys, keyCase{Keys: strings.Join(fieldKeys[i], ", "), Field: i})
+	}
+	code.CaseInsensitive = len(fieldMap) > 0 && isCaseInsensitive(fieldMap)
+	if code.CaseInsensitive {
+		lowers := make([]string, 0, len(lowerKeys))
+		for k := range lowerKeys {
+			lowers = append(lowers, k)
+		}
+		sort.Strings(lowers)
+		for _, k := range lowers {
+			code.LowerKeys = append(code.LowerKeys, keyCase{Keys: strconv.Quote(k), Field: lowerKeys[k]})
+		}
+	}
+}
+
+func (g *generator) decodeField(f *field) string {
+	var stmts []string
+	if s := f.path[0]; s.isPtr && s.unexported {
+		name := types.TypeString(s.elem, func(pkg *types.Package) string { return pkg.Name() })
+		return fmt.Sprintf("return jsongen.EmbeddedPointerError(%s)", strconv.Quote(name))
+	}
+	sels := make([]string, 0, len(f.path))
+	for i, s := range f.path {
+		sels = append(sels, s.name)
+		if s.isPtr && i < len(f.path)-1 {
+			sel := "v." + strings.Join(sels, ".")
+			stmts = append(stmts, fmt.Sprintf("if %s == nil {\n\t%s = new(%s)\n}", sel, sel, g.typeString(s.elem)))
+		}
+	}
+	sel := f.selector()
+	opt := valueOption{
+		quoted:     f.tag.IsString,
+		format:     f.tag.Format,
+		structName: f.structName,
+		fieldName:  f.name(),
+	}
+	if g.isInlineField(f.typ, f.tag, true) {
+		stmts = append(stmts, g.decodeValue(sel, f.typ, opt, 0)...)
+	} else {
+		stmts = append(stmts, returnErr(fmt.Sprintf("l.Decode(&%s, %s, %q, %q)", sel, strconv.Quote(f.rawTag), opt.structName, opt.fieldName)))
+	}
+	return strings.Join(stmts, "\n")
+}
+
+// fallback returns the statement decoding the value into expr by the runtime, for the values that the lexer cannot read.
+func (g *generator) fallback(expr string, opt valueOption) string {
+	return returnErr(fmt.Sprintf("l.Decode(%s, %s, %q, %q)", addr(expr), strconv.Quote(opt.tag()), opt.structName, opt.fieldName))
+}
+
+// decodeValue returns the statements decoding the value into expr.
+func (g *generator) decodeValue(expr string, typ types.Type, opt valueOption, depth int) []string {
+	switch {
+	case isTimeType(typ):
+		opt.quoted = false
+		return []string{fmt.Sprintf("if x, ok := l.Time(%s); ok {\n\t%s = x\n} else %s", strconv.Quote(opt.format), expr, g.fallback(expr, opt))}
+	case g.isRuntimeType(typ):
+		opt.quoted, opt.format = false, ""
+		return []string{g.fallback(expr, opt)}
+	}
+	if kind, bits := basicKind(typ); kind != "" {
+		opt.format = ""
+		return []string{fmt.Sprintf("if x, ok := %s; ok {\n\t%s = %s(x)\n} else %s", readFunc(kind, bits, opt.quoted), expr, g.typeString(typ), g.fallback(expr, opt))}
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Pointer:
+		elem := g.typeString(t.Elem())
+		if g.isRuntimeType(t.Elem()) {
+			opt.quoted, opt.format = false, ""
+			return []string{g.fallback(expr, opt)}
+		}
+		if opt.quoted {
+			// the runtime sets nil for "null" in the string, which is decoded with the pointer
+			kind, bits := basicKind(t.Elem())
+			return []string{fmt.Sprintf("if l.Null() {\n\t%s = nil\n} else if x, ok := %s; ok {\n\tif %s == nil {\n\t\t%s = new(%s)\n\t}\n\t*%s = %s(x)\n} else %s",
+				expr, readFunc(kind, bits, true), expr, expr, elem, expr, elem, g.fallback(expr, opt))}
+		}
+		stmts := []string{fmt.Sprintf("if l.Null() {\n\t%s = nil\n} else {\n\tif %s == nil {\n\t\t%s = new(%s)\n\t}", expr, expr, expr, elem)}
+		stmts = append(stmts, g.decodeValue("*"+expr, t.Elem(), opt, depth)...)
+		return append(stmts, "}")
+	case *types.Slice:
+		opt.quoted = false
+		if isByteSlice(t) {
+			// the runtime leaves the bytes for null
+			return []string{fmt.Sprintf("if x, ok := l.Bytes(); ok {\n\t%s = %s(x)\n} else %s", expr, g.typeString(typ), g.fallback(expr, opt))}
+		}
+		s, i, more := fmt.Sprintf("s%d", depth), fmt.Sprintf("i%d", depth), fmt.Sprintf("more%d", depth)
+		stmts := []string{
+			fmt.Sprintf("if l.Null() {\n\t%s = nil\n} else if %s, ok := l.BeginArray(); !ok {\n%s\n} else {", expr, more, g.fallback(expr, opt)),
+			// the elements are decoded into a new slice, so that the slice is not modified on errors as the runtime does
+			fmt.Sprintf("%s := make(%s, 0, %d)", s, g.typeString(typ), g.initialCap(t.Elem())),
+			fmt.Sprintf("for %s := 0; %s; %s++ {", i, more, i),
+			fmt.Sprintf("if %s < len(%s) {\n\t%s = append(%s, %s[%s])\n} else {\n\t%s = append(%s, %s)\n}", i, expr, s, s, operand(expr), i, s, s, g.zero(t.Elem())),
+		}
+		stmts = append(stmts, g.decodeValue(fmt.Sprintf("%s[%s]", s, i), t.Elem(), opt, depth+1)...)
+		stmts = append(stmts, fmt.Sprintf("if %s, err = l.NextElement(); err != nil {\n\treturn err\n}", more), "}")
+		return append(stmts, fmt.Sprintf("%s = %s", expr, s), "}")
+	case *types.Struct:
+		return []string{returnErr(fmt.Sprintf("%s(l, %s)", g.structCode(typ).DecodeFunc, addr(expr)))}
+	}
+	panic(fmt.Sprintf("jsongen: unexpected type %s", typ))
+}
+
+// readFunc returns the call of the lexer reading the basic kind.
+func readFunc(kind string, bits int, quoted bool) string {
+	prefix := "l."
+	if quoted {
+		prefix = "l.Quoted"
+	}
+	switch kind {
+	case "string":
+		return prefix + "String()"
+	case "bool":
+		return prefix + "Bool()"
+	case "int":
+		return fmt.Sprintf("%sInt(%d)", prefix, bits)
+	case "uint":
+		return fmt.Sprintf("%sUint(%d)", prefix, bits)
+	}
+	return prefix + "Float()"
+}
+
+// initialCap returns the capacity of the slices allocated for decoding, which holds the elements in 64 bytes.
+func (g *generator) initialCap(elem types.Type) int64 {
+	size := types.SizesFor("gc", "amd64").Sizeof(elem)
+	if size == 0 || size >= 64 {
+		return 1
+	}
+	return 64 / size
+}
+
+// zero returns the zero value of typ appended to the slices before decoding the elements.
+func (g *generator) zero(typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsString != 0:
+			return `""`
+		case t.Info()&types.IsBoolean != 0:
+			return "false"
+		}
+		return "0"
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface, *types.Signature, *types.Chan:
+		return "nil"
+	}
+	return g.typeString(typ) + "{}"
+}
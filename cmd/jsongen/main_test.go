@@ -0,0 +1,30 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "jsongen")
+	out := filepath.Join(dir, "types_json.go")
+	pkg, err := loadPackage(dir, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(pkg, []string{"Order", "Conflict", "Nested", "Record"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(expected) {
+		t.Errorf("%s is not up to date; run go generate in %s", out, dir)
+	}
+	if _, err := generate(pkg, []string{"Status"}); err == nil {
+		t.Error("expected error for the non-struct type")
+	}
+}
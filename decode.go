@@ -26,9 +26,6 @@ type emptyInterface struct {
 }
 
 func unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
-	src := make([]byte, len(data)+1) // append nul byte to the end
-	copy(src, data)
-
 	header := (*emptyInterface)(unsafe.Pointer(&v))
 
 	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
@@ -39,11 +36,12 @@ func unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
 		return err
 	}
 	ctx := decoder.TakeRuntimeContext()
-	ctx.Buf = src
 	ctx.Option.Flags = 0
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	src := decodeSource(data, ctx.Option)
+	ctx.Buf = src
 	cursor, err := dec.Decode(ctx, 0, 0, header.ptr)
 	if err != nil {
 		decoder.ReleaseRuntimeContext(ctx)
@@ -54,9 +52,6 @@ func unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
 }
 
 func unmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
-	src := make([]byte, len(data)+1) // append nul byte to the end
-	copy(src, data)
-
 	header := (*emptyInterface)(unsafe.Pointer(&v))
 
 	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
@@ -67,13 +62,14 @@ func unmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs
 		return err
 	}
 	rctx := decoder.TakeRuntimeContext()
-	rctx.Buf = src
 	rctx.Option.Flags = 0
 	rctx.Option.Flags |= decoder.ContextOption
 	rctx.Option.Context = ctx
 	for _, optFunc := range optFuncs {
 		optFunc(rctx.Option)
 	}
+	src := decodeSource(data, rctx.Option)
+	rctx.Buf = src
 	cursor, err := dec.Decode(rctx, 0, 0, header.ptr)
 	if err != nil {
 		decoder.ReleaseRuntimeContext(rctx)
@@ -84,9 +80,6 @@ func unmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs
 }
 
 func unmarshalNoEscape(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
-	src := make([]byte, len(data)+1) // append nul byte to the end
-	copy(src, data)
-
 	header := (*emptyInterface)(unsafe.Pointer(&v))
 
 	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
@@ -98,11 +91,12 @@ func unmarshalNoEscape(data []byte, v interface{}, optFuncs ...DecodeOptionFunc)
 	}
 
 	ctx := decoder.TakeRuntimeContext()
-	ctx.Buf = src
 	ctx.Option.Flags = 0
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	src := decodeSource(data, ctx.Option)
+	ctx.Buf = src
 	cursor, err := dec.Decode(ctx, 0, 0, noescape(header.ptr))
 	if err != nil {
 		decoder.ReleaseRuntimeContext(ctx)
@@ -112,11 +106,43 @@ func unmarshalNoEscape(data []byte, v interface{}, optFuncs ...DecodeOptionFunc)
 	return validateEndBuf(src, cursor)
 }
 
+// decodeSource returns the buffer to decode data, which usually is a copy of data followed by nul.
+// Under NoCopyOption, data is decoded in place if it is followed by nul within its capacity, or if it is an object or an array.
+// The decoders stop at the closing bracket of a valid object or array, and check the end of the buffer for a truncated one.
+// The other values are copied since the decoders of the numbers and the literals need nul to find their end.
+func decodeSource(data []byte, opt *decoder.Option) []byte {
+	if opt.Flags&decoder.NoCopyOption != 0 {
+		if cap(data) > len(data) {
+			if src := data[:len(data)+1]; src[len(data)] == nul {
+				return src
+			}
+		}
+		if isContainer(data) {
+			return data
+		}
+	}
+	src := make([]byte, len(data)+1) // append nul byte to the end
+	copy(src, data)
+	return src
+}
+
+func isContainer(data []byte) bool {
+	for _, c := range data {
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case '{', '[':
+			return true
+		}
+		return false
+	}
+	return false
+}
+
 func validateEndBuf(src []byte, cursor int64) error {
-	for {
+	for ; cursor < int64(len(src)); cursor++ {
 		switch src[cursor] {
 		case ' ', '\t', '\n', '\r':
-			cursor++
 			continue
 		case nul:
 			return nil
@@ -126,6 +152,7 @@ func validateEndBuf(src []byte, cursor int64) error {
 			cursor+1,
 		)
 	}
+	return nil
 }
 
 //nolint:staticcheck
@@ -230,3 +257,21 @@ func (d *Decoder) InputOffset() int64 {
 func (d *Decoder) UseNumber() {
 	d.s.UseNumber = true
 }
+
+// UseBigNumber causes the Decoder to unmarshal an integer that does not fit in int64
+// into an interface{} as a *big.Int instead of as a float64.
+func (d *Decoder) UseBigNumber() {
+	d.s.Option.Flags |= decoder.UseBigNumberOption
+}
+
+// UseInt64 causes the Decoder to unmarshal an integer into an interface{} as an int64
+// instead of as a float64.
+func (d *Decoder) UseInt64() {
+	d.s.Option.Flags |= decoder.UseInt64Option
+}
+
+// UseOrderedObject causes the Decoder to unmarshal an object into an interface{} as an OrderedObject
+// instead of as a map[string]interface{}.
+func (d *Decoder) UseOrderedObject() {
+	d.s.Option.Flags |= decoder.UseOrderedObjectOption
+}
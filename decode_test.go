@@ -115,6 +115,22 @@ func Test_Decoder(t *testing.T) {
 		assertErr(t, json.Unmarshal([]byte(` [ 1 , 2 , 3 , 4 ] `), &v))
 		assertEq(t, "array", fmt.Sprint([4]int{1, 2, 3, 4}), fmt.Sprint(v))
 	})
+	t.Run("array_zero_small_elems", func(t *testing.T) {
+		v := struct {
+			A [3]byte
+			B [2]string
+			C uint32
+		}{A: [3]byte{7, 8, 9}, B: [2]string{"x", "y"}, C: 5}
+		assertErr(t, json.Unmarshal([]byte(`{"A":[1],"B":["a"]}`), &v))
+		assertEq(t, "byte array", [3]byte{1, 0, 0}, v.A)
+		assertEq(t, "string array", [2]string{"a", ""}, v.B)
+		assertEq(t, "next field", uint32(5), v.C)
+
+		v.A = [3]byte{7, 8, 9}
+		assertErr(t, json.NewDecoder(strings.NewReader(`{"A":[2,3]}`)).Decode(&v))
+		assertEq(t, "stream byte array", [3]byte{2, 3, 0}, v.A)
+		assertEq(t, "stream next field", uint32(5), v.C)
+	})
 	t.Run("map", func(t *testing.T) {
 		var v map[string]int
 		assertErr(t, json.Unmarshal([]byte(` { "a": 1, "b": 2, "c": 3, "d": 4 } `), &v))
@@ -3776,3 +3792,482 @@ func TestIssue282(t *testing.T) {
 		t.Fatalf("failed to assign map value")
 	}
 }
+
+func TestDecodeBigNumber(t *testing.T) {
+	type T struct {
+		A *big.Int   `json:"a"`
+		B *big.Float `json:"b"`
+		C *big.Rat   `json:"c"`
+		D big.Int    `json:"d"`
+		E big.Float  `json:"e"`
+	}
+	src := `{"a":123456789012345678901234567890,"b":1234567890.123456789012345678,"c":1.25,"d":-7,"e":null}`
+	assertBigNumber := func(t *testing.T, v T) {
+		t.Helper()
+		assertEq(t, "big.Int", "123456789012345678901234567890", v.A.String())
+		assertEq(t, "big.Float", "1234567890.123456789012345678", v.B.Text('f', 18))
+		assertEq(t, "big.Rat", "5/4", v.C.String())
+		assertEq(t, "big.Int", "-7", v.D.String())
+		assertEq(t, "big.Float", "0", v.E.String())
+	}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		assertBigNumber(t, v)
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v T
+		assertErr(t, json.NewDecoder(strings.NewReader(src)).Decode(&v))
+		assertBigNumber(t, v)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var v T
+		err := json.Unmarshal([]byte(`{"a":1.5}`), &v)
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Fatalf("expected UnmarshalTypeError but got %v", err)
+		}
+		if err := json.Unmarshal([]byte(`{"c":"1/3"}`), &v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("UseBigNumber", func(t *testing.T) {
+		src := `[1, 1.5, 123456789012345678901234567890]`
+		assertNumbers := func(t *testing.T, v []interface{}) {
+			t.Helper()
+			assertEq(t, "small integer", float64(1), v[0])
+			assertEq(t, "fraction", float64(1.5), v[1])
+			n, ok := v[2].(*big.Int)
+			if !ok {
+				t.Fatalf("expected *big.Int but got %T", v[2])
+			}
+			assertEq(t, "big integer", "123456789012345678901234567890", n.String())
+		}
+		var v1 []interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v1, json.DecodeUseBigNumber()))
+		assertNumbers(t, v1)
+
+		var v2 []interface{}
+		dec := json.NewDecoder(strings.NewReader(src))
+		dec.UseBigNumber()
+		assertErr(t, dec.Decode(&v2))
+		assertNumbers(t, v2)
+	})
+}
+
+func TestDecodeUseInt64(t *testing.T) {
+	src := `[1, -2, 1.5, 1e3, 9223372036854775808, 18446744073709551616, {"a":[3]}, -0, 0]`
+	assertNumbers := func(t *testing.T, v []interface{}) {
+		t.Helper()
+		assertEq(t, "integer", int64(1), v[0])
+		assertEq(t, "negative integer", int64(-2), v[1])
+		assertEq(t, "fraction", float64(1.5), v[2])
+		assertEq(t, "exponent", float64(1000), v[3])
+		assertEq(t, "uint64", uint64(9223372036854775808), v[4])
+		assertEq(t, "overflow", float64(18446744073709551616), v[5])
+		assertEq(t, "nested", int64(3), v[6].(map[string]interface{})["a"].([]interface{})[0])
+		if f, ok := v[7].(float64); !ok || !math.Signbit(f) {
+			t.Fatalf("expected negative zero float64 but got %T(%v)", v[7], v[7])
+		}
+		assertEq(t, "zero", int64(0), v[8])
+	}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v []interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeUseInt64()))
+		assertNumbers(t, v)
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v []interface{}
+		dec := json.NewDecoder(strings.NewReader(src))
+		dec.UseInt64()
+		assertErr(t, dec.Decode(&v))
+		assertNumbers(t, v)
+	})
+	t.Run("UseBigNumber", func(t *testing.T) {
+		var v []interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeUseInt64(), json.DecodeUseBigNumber()))
+		assertEq(t, "integer", int64(1), v[0])
+		if _, ok := v[4].(*big.Int); !ok {
+			t.Fatalf("expected *big.Int but got %T", v[4])
+		}
+	})
+}
+
+func TestDecodeOrderedObject(t *testing.T) {
+	src := `{"z":1,"a":{"y":[true,{"q":null}]},"m":"s","z":2}`
+	assertObject := func(t *testing.T, v interface{}) {
+		t.Helper()
+		obj, ok := v.(json.OrderedObject)
+		if !ok {
+			t.Fatalf("expected json.OrderedObject but got %T", v)
+		}
+		assertEq(t, "keys", fmt.Sprint([]string{"z", "a", "m", "z"}), fmt.Sprint(obj.Keys()))
+		z, _ := obj.Get("z")
+		assertEq(t, "duplicated key", float64(2), z)
+		a, _ := obj.Get("a")
+		y, _ := a.(json.OrderedObject).Get("y")
+		nested, ok := y.([]interface{})[1].(json.OrderedObject)
+		if !ok {
+			t.Fatalf("expected nested json.OrderedObject but got %T", y.([]interface{})[1])
+		}
+		assertEq(t, "nested keys", fmt.Sprint([]string{"q"}), fmt.Sprint(nested.Keys()))
+	}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeUseOrderedObject()))
+		assertObject(t, v)
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v interface{}
+		dec := json.NewDecoder(strings.NewReader(src))
+		dec.UseOrderedObject()
+		assertErr(t, dec.Decode(&v))
+		assertObject(t, v)
+	})
+	t.Run("field", func(t *testing.T) {
+		var v struct {
+			A json.OrderedObject  `json:"a"`
+			B *json.OrderedObject `json:"b"`
+		}
+		assertErr(t, json.Unmarshal([]byte(`{"a":{"y":1,"x":{"w":2}},"b":{}}`), &v))
+		assertEq(t, "keys", fmt.Sprint([]string{"y", "x"}), fmt.Sprint(v.A.Keys()))
+		x, _ := v.A.Get("x")
+		if _, ok := x.(map[string]interface{}); !ok {
+			t.Fatalf("expected map[string]interface{} without option but got %T", x)
+		}
+		assertEq(t, "empty", 0, len(*v.B))
+	})
+}
+
+func TestDecodeTimeFormat(t *testing.T) {
+	type T struct {
+		A time.Time  `json:"a"`
+		B time.Time  `json:"b,format=unixmilli"`
+		C *time.Time `json:"c,format=unix"`
+		E time.Time  `json:"e,format=RFC1123"`
+	}
+	src := `{"a":"2021-03-04T05:06:07.8Z","b":1614834367800,"c":-1.5,"e":"Thu, 04 Mar 2021 05:06:07 UTC"}`
+	assertT := func(t *testing.T, v T) {
+		t.Helper()
+		tm := time.Date(2021, 3, 4, 5, 6, 7, 800000000, time.UTC)
+		assertEq(t, "default", true, v.A.Equal(tm))
+		assertEq(t, "unixmilli", true, v.B.Equal(tm))
+		assertEq(t, "unix", true, v.C.Equal(time.Unix(-2, 500000000)))
+		assertEq(t, "layout", true, v.E.Equal(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)))
+	}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		assertT(t, v)
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v T
+		assertErr(t, json.NewDecoder(strings.NewReader(src)).Decode(&v))
+		assertT(t, v)
+	})
+	t.Run("DecodeTimeFormat", func(t *testing.T) {
+		var v []time.Time
+		assertErr(t, json.UnmarshalWithOption([]byte(`[1614834367, null, 0.000000001]`), &v, json.DecodeTimeFormat("unix")))
+		assertEq(t, "length", 3, len(v))
+		assertEq(t, "unix", true, v[0].Equal(time.Unix(1614834367, 0)))
+		assertEq(t, "null", true, v[1].IsZero())
+		assertEq(t, "fraction", true, v[2].Equal(time.Unix(0, 1)))
+	})
+	t.Run("type error", func(t *testing.T) {
+		var v T
+		err := json.Unmarshal([]byte(`{"b":"2021-03-04"}`), &v)
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError but got %T", err)
+		}
+		err = json.NewDecoder(strings.NewReader(`{"a":1}`)).Decode(&v)
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError but got %T", err)
+		}
+	})
+	t.Run("layout error", func(t *testing.T) {
+		src := `{"e":"2021-03-04"}`
+		var v T
+		for _, err := range []error{
+			json.Unmarshal([]byte(src), &v),
+			json.NewDecoder(strings.NewReader(src)).Decode(&v),
+		} {
+			typeErr, ok := err.(*json.UnmarshalTypeError)
+			if !ok {
+				t.Fatalf("expected *json.UnmarshalTypeError but got %T", err)
+			}
+			assertEq(t, "struct", "T", typeErr.Struct)
+			assertEq(t, "field", "E", typeErr.Field)
+		}
+	})
+	t.Run("elements", func(t *testing.T) {
+		type U struct {
+			A []time.Time            `json:"a,format=2006-01-02"`
+			B *[]*time.Time          `json:"b,format=unix"`
+			C map[string]time.Time   `json:"c,format=2006-01-02"`
+			D [1]time.Time           `json:"d,format=unixmilli"`
+			E map[string][]time.Time `json:"e,format=unix"`
+		}
+		src := `{"a":["2021-03-04",null],"b":[1614834367,null],"c":{"k":"2021-03-04"},"d":[1614834367800],"e":{"k":[0]}}`
+		date := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+		tm := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		for _, decode := range []func(*U) error{
+			func(v *U) error { return json.Unmarshal([]byte(src), v) },
+			func(v *U) error { return json.NewDecoder(strings.NewReader(src)).Decode(v) },
+		} {
+			var v U
+			assertErr(t, decode(&v))
+			assertEq(t, "slice", true, v.A[0].Equal(date))
+			assertEq(t, "null", true, v.A[1].IsZero())
+			assertEq(t, "pointer", true, (*v.B)[0].Equal(tm))
+			assertEq(t, "nil", true, (*v.B)[1] == nil)
+			assertEq(t, "map", true, v.C["k"].Equal(date))
+			assertEq(t, "array", true, v.D[0].Equal(tm.Add(800*time.Millisecond)))
+			assertEq(t, "nested", true, v.E["k"][0].Equal(time.Unix(0, 0)))
+		}
+	})
+}
+
+func TestDecodeBytesEncoding(t *testing.T) {
+	type T struct {
+		A []byte  `json:"a"`
+		B []byte  `json:"b,format=rawbase64url"`
+		C *[]byte `json:"c,format=hex"`
+		D []byte  `json:"d,format=array"`
+		E [2]byte `json:"e,format=base64"`
+	}
+	src := `{"a":"+/8=","b":"-_8","c":"fbff","d":[251,255],"e":"+/8="}`
+	assertT := func(t *testing.T, v T) {
+		t.Helper()
+		b := []byte{0xfb, 0xff}
+		assertEq(t, "default", string(b), string(v.A))
+		assertEq(t, "rawbase64url", string(b), string(v.B))
+		assertEq(t, "hex", string(b), string(*v.C))
+		assertEq(t, "array", string(b), string(v.D))
+		assertEq(t, "byte array", [2]byte{0xfb, 0xff}, v.E)
+	}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		assertT(t, v)
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v T
+		assertErr(t, json.NewDecoder(strings.NewReader(src)).Decode(&v))
+		assertT(t, v)
+	})
+	t.Run("DecodeBytesEncoding", func(t *testing.T) {
+		var s struct {
+			B []byte
+			A [3]byte
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"B":"fbff","A":"010203"}`), &s, json.DecodeBytesEncoding("hex")))
+		assertEq(t, "hex", "\xfb\xff", string(s.B))
+		assertEq(t, "byte array", [3]byte{1, 2, 3}, s.A)
+	})
+	t.Run("DecodeAnyBytesEncoding", func(t *testing.T) {
+		var v [][]byte
+		assertErr(t, json.UnmarshalWithOption([]byte(`["+/8=","-_8",[251,255],"fbff",null]`), &v, json.DecodeBytesEncoding("hex"), json.DecodeAnyBytesEncoding()))
+		assertEq(t, "length", 5, len(v))
+		for i, b := range v[:4] {
+			assertEq(t, fmt.Sprintf("bytes[%d]", i), "\xfb\xff", string(b))
+		}
+		assertEq(t, "null", true, v[4] == nil)
+	})
+	t.Run("type error", func(t *testing.T) {
+		var v T
+		err := json.Unmarshal([]byte(`{"a":[1,2]}`), &v)
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError but got %T", err)
+		}
+		err = json.NewDecoder(strings.NewReader(`{"d":"+/8="}`)).Decode(&v)
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError but got %T", err)
+		}
+		err = json.Unmarshal([]byte(`{"e":"+/+/"}`), &v)
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError but got %T", err)
+		}
+	})
+}
+
+func TestDecodeZeroCopy(t *testing.T) {
+	type T struct {
+		A string
+		B string
+		C int `json:",string"`
+		M map[string]string
+	}
+	const input = `{"A":"hello","B":"x\ny\u3042\ud83d\ude00","C":"1","M":{"k":"v"}}`
+	t.Run("in place", func(t *testing.T) {
+		buf := make([]byte, len(input)+1)
+		data := buf[:copy(buf, input)]
+		var v T
+		assertErr(t, json.UnmarshalWithOption(data, &v, json.DecodeZeroCopy()))
+		assertEq(t, "input", input, string(data))
+		assertEq(t, "B", "x\nyあ\U0001F600", v.B)
+		assertEq(t, "C", 1, v.C)
+		assertEq(t, "M", "v", v.M["k"])
+
+		// A points into data
+		data[strings.Index(input, "hello")] = 'H'
+		assertEq(t, "A", "Hello", v.A)
+	})
+	t.Run("without sentinel", func(t *testing.T) {
+		data := []byte(input + " \n")
+		var v T
+		assertErr(t, json.UnmarshalWithOption(data[:len(data):len(data)], &v, json.DecodeZeroCopy()))
+		assertEq(t, "B", "x\nyあ\U0001F600", v.B)
+		data[strings.Index(input, "hello")] = 'H'
+		assertEq(t, "A", "Hello", v.A)
+
+		var nums []interface{}
+		data = []byte(`[1, -2.5e3, true, null]`)
+		assertErr(t, json.UnmarshalWithOption(data[:len(data):len(data)], &nums, json.DecodeZeroCopy()))
+		assertEq(t, "nums", "[1 -2500 true <nil>]", fmt.Sprint(nums))
+	})
+	t.Run("copy", func(t *testing.T) {
+		data := []byte(`"hello"`)
+		var v string
+		assertErr(t, json.UnmarshalWithOption(data[:len(data):len(data)], &v, json.DecodeZeroCopy()))
+		data[1] = 'H'
+		assertEq(t, "v", "hello", v)
+	})
+	t.Run("no copy", func(t *testing.T) {
+		data := []byte(input)
+		var v T
+		assertErr(t, json.UnmarshalWithOption(data[:len(data):len(data)], &v, json.DecodeNoCopy()))
+		assertEq(t, "B", "x\nyあ\U0001F600", v.B)
+		assertEq(t, "C", 1, v.C)
+
+		// the strings do not point into data
+		for i := range data {
+			data[i] = 'X'
+		}
+		assertEq(t, "A", "hello", v.A)
+		assertEq(t, "M", "v", v.M["k"])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, src := range []string{
+			`{"A":"hello"`, `{"A":"hello"}x`, `[1,]`, `[tru]`, `{"A":"\u12"}`,
+			`{"A":"hel`, `{"A"`, `{"C":"1`, `{"M":{"k"`, `[1`, `[-`, `{`, `[`,
+		} {
+			for _, opt := range []json.DecodeOptionFunc{json.DecodeNoCopy(), json.DecodeZeroCopy()} {
+				data := []byte(src)
+				var v T
+				if err := json.UnmarshalWithOption(data[:len(data):len(data)], &v, opt); err == nil {
+					t.Errorf("expected error for %s", src)
+				}
+				var i interface{}
+				if err := json.UnmarshalWithOption(data[:len(data):len(data)], &i, opt); err == nil {
+					t.Errorf("expected error for %s", src)
+				}
+			}
+		}
+	})
+	t.Run("truncated", func(t *testing.T) {
+		type U struct {
+			T
+			D []interface{}       `json:"d"`
+			N json.Number         `json:"n"`
+			O json.OrderedObject  `json:"o"`
+			P *float64            `json:"p"`
+			Q [2]byte             `json:"q,format=hex"`
+			R json.RawMessage     `json:"r"`
+			S time.Time           `json:"s,format=unix"`
+			X map[int]interface{} `json:"x"`
+		}
+		const src = `{"A":"a\u00e9","C":"12","M":{"k":"v"},"d":[true,false,null,-1.5e3,{"x":[]}],"n":12,` +
+			`"o":{"b":1},"p":0.5,"q":"0102","r":{"z":[1]},"s":1600000000,"x":{"1":"y"}}`
+		for i := 0; i <= len(src); i++ {
+			for _, newValue := range []func() interface{}{
+				func() interface{} { return new(U) },
+				func() interface{} { return new(interface{}) },
+				func() interface{} { return new(json.OrderedObject) },
+			} {
+				expected := json.Unmarshal([]byte(src[:i]), newValue())
+				data := []byte(src[:i])
+				err := json.UnmarshalWithOption(data[:i:i], newValue(), json.DecodeNoCopy())
+				if (err == nil) != (expected == nil) {
+					t.Fatalf("unexpected result for %s: %v (expected %v)", src[:i], err, expected)
+				}
+			}
+		}
+	})
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected the panic of UnmarshalJSON")
+			}
+		}()
+		data := []byte(`[{}]`)
+		var v []panicUnmarshaler
+		_ = json.UnmarshalWithOption(data[:len(data):len(data)], &v, json.DecodeNoCopy())
+	})
+}
+
+type panicUnmarshaler struct{}
+
+func (*panicUnmarshaler) UnmarshalJSON(b []byte) error {
+	_ = b[len(b)+1] // panics with "index out of range"
+	return nil
+}
+
+func TestDecodeParallel(t *testing.T) {
+	type T struct {
+		ID   int
+		Name string
+		Tags []string
+	}
+	var b strings.Builder
+	b.WriteString("[")
+	for i := 0; i < 1000; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, `{"ID":%d,"Name":"nA%d","Tags":["a","b"]}`, i, i)
+	}
+	b.WriteString("]")
+	input := b.String()
+	t.Run("decode", func(t *testing.T) {
+		var expected, got []T
+		assertErr(t, json.Unmarshal([]byte(input), &expected))
+		for _, workers := range []int{0, 1, 3, 64} {
+			got = nil
+			assertErr(t, json.UnmarshalWithOption([]byte(input), &got, json.DecodeParallel(workers)))
+			assertEq(t, "len", len(expected), len(got))
+			assertEq(t, "result", fmt.Sprint(expected), fmt.Sprint(got))
+		}
+	})
+	t.Run("pointer", func(t *testing.T) {
+		first := &T{ID: -1}
+		got := []*T{first}
+		assertErr(t, json.UnmarshalWithOption([]byte(input), &got, json.DecodeParallel(4)))
+		assertEq(t, "len", 1000, len(got))
+		assertEq(t, "first", first, got[0])
+		assertEq(t, "ID", 0, first.ID)
+		assertEq(t, "last", "nA999", got[999].Name)
+	})
+	t.Run("error", func(t *testing.T) {
+		src := strings.Replace(input, `"ID":300`, `"ID":"x"`, 1)
+		src = strings.Replace(src, `"ID":900`, `"ID":true`, 1)
+		var expected []T
+		expectedErr := json.Unmarshal([]byte(src), &expected)
+		if expectedErr == nil {
+			t.Fatal("expected error")
+		}
+		for _, workers := range []int{2, 8} {
+			got := []T{{ID: -1}}
+			err := json.UnmarshalWithOption([]byte(src), &got, json.DecodeParallel(workers))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			assertEq(t, "error", expectedErr.Error(), err.Error())
+			assertEq(t, "unmodified", 1, len(got))
+		}
+		var got []T
+		err := json.UnmarshalWithOption([]byte(input[:len(input)-1]), &got, json.DecodeParallel(2))
+		if err == nil {
+			t.Fatal("expected error for truncated input")
+		}
+	})
+}
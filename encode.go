@@ -3,6 +3,7 @@ package json
 import (
 	"context"
 	"io"
+	"reflect"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/encoder"
@@ -19,6 +20,9 @@ type Encoder struct {
 	enabledHTMLEscape bool
 	prefix            string
 	indentStr         string
+	flushThreshold    int
+	tokens            []tokenFrame
+	tokenBuf          []byte
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -64,24 +68,49 @@ func (e *Encoder) encodeWithOption(ctx *encoder.RuntimeContext, v interface{}, o
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	if e.flushThreshold > 0 {
+		ctx.Option.Flag |= encoder.FlushOption
+		ctx.Writer = e.w
+		ctx.FlushThreshold = e.flushThreshold
+		ctx.SortedMapDepth = 0
+		defer func() { ctx.Writer = nil }()
+	}
+	prefix := e.prefix
+	b := ctx.Buf[:0]
+	if len(e.tokens) > 0 {
+		// v is a value in the object or the array written by the token-level API.
+		// The separator is written with the value, so that nothing is written if v cannot be encoded.
+		sep, err := e.appendValueSeparator(e.tokenBuf[:0])
+		if err != nil {
+			return err
+		}
+		e.tokenBuf = sep
+		b = append(b, sep...)
+		prefix = e.tokenIndentPrefix()
+	}
 	var (
 		buf []byte
 		err error
 	)
 	if e.enabledIndent {
-		buf, err = encodeIndent(ctx, v, e.prefix, e.indentStr)
+		buf, err = encodeIndentTo(ctx, b, v, prefix, e.indentStr)
 	} else {
-		buf, err = encode(ctx, v)
+		buf, err = encodeTo(ctx, b, v)
 	}
 	if err != nil {
 		return err
 	}
+	if len(e.tokens) > 0 {
+		e.valueWritten()
+	}
 	if e.enabledIndent {
 		buf = buf[:len(buf)-2]
 	} else {
 		buf = buf[:len(buf)-1]
 	}
-	buf = append(buf, '\n')
+	if len(e.tokens) == 0 {
+		buf = append(buf, '\n')
+	}
 	if _, err := e.w.Write(buf); err != nil {
 		return err
 	}
@@ -96,6 +125,17 @@ func (e *Encoder) SetEscapeHTML(on bool) {
 	e.enabledHTMLEscape = on
 }
 
+// SetFlushThreshold makes the encoder write the encoded bytes to the stream whenever they exceed n bytes
+// while encoding the elements of slices, arrays and maps, so that a huge value is written with bounded memory.
+// Values smaller than n are written at once as before. n <= 0 disables flushing ( default ).
+//
+// Sorted maps are buffered until all the keys are encoded, so use UnorderedMap option to stream huge maps.
+// A slice split by the Parallel option is also buffered in full and written at once.
+// If an error occurs after flushing, the stream has a partially written value.
+func (e *Encoder) SetFlushThreshold(n int) {
+	e.flushThreshold = n
+}
+
 // SetIndent instructs the encoder to format each subsequent encoded value as if indented by the package-level function Indent(dst, src, prefix, indent).
 // Calling SetIndent("", "") disables indentation.
 func (e *Encoder) SetIndent(prefix, indent string) {
@@ -108,6 +148,15 @@ func (e *Encoder) SetIndent(prefix, indent string) {
 	e.enabledIndent = true
 }
 
+// RegisterIterator registers the type of fn, which must be a func of the form func() (T, bool),
+// so that its values are encoded as a JSON array.
+// While encoding, the func is called until it returns false and each returned T becomes an element of the array.
+// Channels that can be received from are encoded in the same way without registration.
+// The type must be registered before a value of it is encoded for the first time.
+func RegisterIterator(fn interface{}) error {
+	return encoder.RegisterIterator(reflect.TypeOf(fn))
+}
+
 func marshalContext(ctx context.Context, v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, error) {
 	rctx := encoder.TakeRuntimeContext()
 	rctx.Option.Flag = 0
@@ -210,7 +259,11 @@ func marshalIndent(v interface{}, prefix, indent string, optFuncs ...EncodeOptio
 }
 
 func encode(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error) {
-	b := ctx.Buf[:0]
+	return encodeTo(ctx, ctx.Buf[:0], v)
+}
+
+// encodeTo appends the encoding of v to b, which must be ctx.Buf or its prefix.
+func encodeTo(ctx *encoder.RuntimeContext, b []byte, v interface{}) ([]byte, error) {
 	if v == nil {
 		b = encoder.AppendNull(ctx, b)
 		b = encoder.AppendComma(ctx, b)
@@ -265,7 +318,11 @@ func encodeNoEscape(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error)
 }
 
 func encodeIndent(ctx *encoder.RuntimeContext, v interface{}, prefix, indent string) ([]byte, error) {
-	b := ctx.Buf[:0]
+	return encodeIndentTo(ctx, ctx.Buf[:0], v, prefix, indent)
+}
+
+// encodeIndentTo appends the indented encoding of v to b, which must be ctx.Buf or its prefix.
+func encodeIndentTo(ctx *encoder.RuntimeContext, b []byte, v interface{}, prefix, indent string) ([]byte, error) {
 	if v == nil {
 		b = encoder.AppendNull(ctx, b)
 		b = encoder.AppendCommaIndent(ctx, b)
@@ -295,6 +352,11 @@ func encodeIndent(ctx *encoder.RuntimeContext, v interface{}, prefix, indent str
 }
 
 func encodeRunCode(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]byte, error) {
+	if (ctx.Option.Flag & encoder.ParallelOption) != 0 {
+		if buf, ok, err := encodeParallel(ctx, b, codeSet, []byte("],"), encodeRunCode); ok {
+			return buf, err
+		}
+	}
 	if (ctx.Option.Flag & encoder.DebugOption) != 0 {
 		if (ctx.Option.Flag & encoder.ColorizeOption) != 0 {
 			return vm_color.DebugRun(ctx, b, codeSet)
@@ -310,6 +372,15 @@ func encodeRunCode(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.Opcod
 func encodeRunIndentCode(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet, prefix, indent string) ([]byte, error) {
 	ctx.Prefix = []byte(prefix)
 	ctx.IndentStr = []byte(indent)
+	if (ctx.Option.Flag & encoder.ParallelOption) != 0 {
+		tail := append(append([]byte{'\n'}, prefix...), "],\n"...)
+		run := func(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]byte, error) {
+			return encodeRunIndentCode(ctx, b, codeSet, prefix, indent)
+		}
+		if buf, ok, err := encodeParallel(ctx, b, codeSet, tail, run); ok {
+			return buf, err
+		}
+	}
 	if (ctx.Option.Flag & encoder.DebugOption) != 0 {
 		if (ctx.Option.Flag & encoder.ColorizeOption) != 0 {
 			return vm_color_indent.DebugRun(ctx, b, codeSet)
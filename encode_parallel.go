@@ -0,0 +1,85 @@
+package json
+
+import (
+	"bytes"
+	"reflect"
+	goruntime "runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+type encodeRunFunc func(*encoder.RuntimeContext, []byte, *encoder.OpcodeSet) ([]byte, error)
+
+// encodeParallel encodes the top-level slice of codeSet by chunks concurrently, and stitches the results together.
+// Each chunk is encoded by run as a slice on its own RuntimeContext, so that it is encoded as '[' elements tail,
+// where tail closes the array and ends the top-level value.
+// It returns false if the value is not a slice worth splitting, and then the caller encodes it sequentially.
+// Only slices are split. Maps are out of scope, since the keys are sorted over the whole map.
+// The chunks are encoded without FlushOption because they are joined after all of them are encoded,
+// so the slice is written at once even if the flush threshold is set.
+func encodeParallel(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet, tail []byte, run encodeRunFunc) ([]byte, bool, error) {
+	if codeSet.NoescapeKeyCode.Op != encoder.OpSlice || ctx.Ptrs[0] == 0 {
+		return nil, false, nil
+	}
+	slice := *(**runtime.SliceHeader)(unsafe.Pointer(&ctx.Ptrs[0]))
+	workers := ctx.Option.Parallelism
+	if workers <= 0 {
+		workers = goruntime.GOMAXPROCS(0)
+	}
+	if workers > slice.Len {
+		workers = slice.Len
+	}
+	if workers < 2 {
+		return nil, false, nil
+	}
+	typ := codeSet.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	size := typ.Elem().Size()
+	chunks := make([][]byte, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w, from, to int) {
+			defer wg.Done()
+			chunk := &runtime.SliceHeader{
+				Data: unsafe.Pointer(uintptr(slice.Data) + uintptr(from)*size),
+				Len:  to - from,
+				Cap:  to - from,
+			}
+			cctx := encoder.TakeRuntimeContext()
+			*cctx.Option = *ctx.Option
+			cctx.Option.Flag &^= encoder.ParallelOption | encoder.FlushOption
+			cctx.Context = ctx.Context
+			cctx.Init(uintptr(unsafe.Pointer(chunk)), codeSet.CodeLength)
+			cctx.KeepRefs = append(cctx.KeepRefs, unsafe.Pointer(chunk))
+			// encode into a new buffer, since the buffer of cctx is reused by others after it is released
+			chunks[w], errs[w] = run(cctx, nil, codeSet)
+			encoder.ReleaseRuntimeContext(cctx)
+		}(w, slice.Len*w/workers, slice.Len*(w+1)/workers)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, true, err
+		}
+	}
+	for _, chunk := range chunks {
+		if len(chunk) < 1+len(tail) || chunk[0] != '[' || !bytes.HasSuffix(chunk, tail) {
+			return nil, false, nil
+		}
+	}
+	b = append(b, '[')
+	for i, chunk := range chunks {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, chunk[1:len(chunk)-len(tail)]...)
+	}
+	return append(b, tail...), true, nil
+}
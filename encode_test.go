@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -2278,3 +2280,643 @@ func TestRecursivePtrHead(t *testing.T) {
 		t.Fatalf("failed to encode. expected %q but got %q", expected, got)
 	}
 }
+
+func TestEncodeFloatFormat(t *testing.T) {
+	type T struct {
+		A float64  `json:"a,precision=2"`
+		B float32  `json:"b"`
+		C *float64 `json:"c,precision=1"`
+		D float64  `json:"d,string,precision=3"`
+	}
+	c := 3.14159
+	v := T{A: 1.23456, B: 1.5, C: &c, D: 2}
+	t.Run("tag", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "precision tag", `{"a":1.23,"b":1.5,"c":3.1,"d":"2.000"}`, string(got))
+	})
+	t.Run("FloatPrecision", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.FloatPrecision(4))
+		assertErr(t, err)
+		assertEq(t, "precision option", `{"a":1.23,"b":1.5000,"c":3.1,"d":"2.000"}`, string(got))
+	})
+	t.Run("FloatExponent", func(t *testing.T) {
+		got, err := json.MarshalWithOption([]float64{1e21, 0.5}, json.FloatExponent(json.FloatExponentNever))
+		assertErr(t, err)
+		assertEq(t, "never", `[1000000000000000000000,0.5]`, string(got))
+		got, err = json.MarshalWithOption([]float64{1e21, 0.5}, json.FloatExponent(json.FloatExponentAlways))
+		assertErr(t, err)
+		assertEq(t, "always", `[1e+21,5e-01]`, string(got))
+	})
+}
+
+func TestEncodeNonFiniteFloat(t *testing.T) {
+	type T struct {
+		A float64  `json:"a"`
+		B *float64 `json:"b"`
+		C float64  `json:"c,string"`
+		D float32  `json:"d"`
+	}
+	inf := math.Inf(1)
+	v := &T{A: math.NaN(), B: &inf, C: math.Inf(-1), D: float32(math.NaN())}
+	t.Run("error", func(t *testing.T) {
+		if _, err := json.Marshal(v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("float32 error", func(t *testing.T) {
+		nan := float32(math.NaN())
+		for _, v := range []interface{}{
+			nan,
+			&nan,
+			[]float32{nan},
+			struct{ F float32 }{nan},
+			struct {
+				F float32 `json:"f,omitempty"`
+			}{nan},
+			struct {
+				F *float32 `json:"f,string"`
+			}{&nan},
+		} {
+			for _, marshal := range []func(interface{}) ([]byte, error){
+				json.Marshal,
+				func(v interface{}) ([]byte, error) { return json.MarshalIndent(v, "", " ") },
+			} {
+				got, err := marshal(v)
+				if _, ok := err.(*json.UnsupportedValueError); !ok {
+					t.Errorf("for %T, expected UnsupportedValueError but got %s, %v", v, got, err)
+				}
+			}
+		}
+	})
+	t.Run("null", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.NonFiniteFloat(json.NonFiniteFloatNull))
+		assertErr(t, err)
+		assertEq(t, "null", `{"a":null,"b":null,"c":null,"d":null}`, string(got))
+	})
+	t.Run("string", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.NonFiniteFloat(json.NonFiniteFloatString))
+		assertErr(t, err)
+		assertEq(t, "string", `{"a":"NaN","b":"+Inf","c":"-Inf","d":"NaN"}`, string(got))
+	})
+	t.Run("indent", func(t *testing.T) {
+		got, err := json.MarshalIndentWithOption([]float64{math.NaN()}, "", " ", json.NonFiniteFloat(json.NonFiniteFloatNull))
+		assertErr(t, err)
+		assertEq(t, "indent", "[\n null\n]", string(got))
+	})
+}
+
+func TestEncodeBigNumber(t *testing.T) {
+	type T struct {
+		A *big.Int   `json:"a"`
+		B *big.Float `json:"b"`
+		C *big.Rat   `json:"c"`
+		D big.Int    `json:"d"`
+		E big.Float  `json:"e"`
+		F *big.Rat   `json:"f"`
+		G *big.Int   `json:"g,omitempty"`
+	}
+	i, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	f, _ := new(big.Float).SetPrec(200).SetString("1234567890.123456789012345678")
+	v := &T{
+		A: i,
+		B: f,
+		C: big.NewRat(5, 4),
+		D: *big.NewInt(-7),
+		E: *big.NewFloat(0.5),
+	}
+	t.Run("struct", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "big number", `{"a":123456789012345678901234567890,"b":1234567890.123456789012345678,"c":1.25,"d":-7,"e":0.5,"f":null}`, string(got))
+	})
+	t.Run("interface", func(t *testing.T) {
+		got, err := json.Marshal([]interface{}{i, big.NewRat(1, 3), big.NewRat(4, 2)})
+		assertErr(t, err)
+		assertEq(t, "big number", `[123456789012345678901234567890,0.33333333333333333333,2]`, string(got))
+	})
+	t.Run("indent", func(t *testing.T) {
+		got, err := json.MarshalIndent(struct {
+			A *big.Float `json:"a"`
+		}{A: big.NewFloat(1e100)}, "", " ")
+		assertErr(t, err)
+		assertEq(t, "big number", "{\n \"a\": 1e+100\n}", string(got))
+	})
+	t.Run("FloatPrecision", func(t *testing.T) {
+		got, err := json.MarshalWithOption([]interface{}{big.NewFloat(1.5), big.NewRat(1, 3)}, json.FloatPrecision(2))
+		assertErr(t, err)
+		assertEq(t, "big number", `[1.50,0.33]`, string(got))
+	})
+	t.Run("precision tag", func(t *testing.T) {
+		type T struct {
+			A *big.Float `json:"a,precision=2"`
+			B *big.Rat   `json:"b,precision=4"`
+			C *big.Rat   `json:"c,string,precision=1"`
+			D *big.Float `json:"d"`
+			E *big.Int   `json:"e,precision=2"`
+		}
+		v := &T{A: big.NewFloat(1.5), B: big.NewRat(1, 3), C: big.NewRat(5, 4), D: big.NewFloat(0.125), E: big.NewInt(7)}
+		assertMarshalAllVM(t, "big number", `{"a":1.50,"b":0.3333,"c":"1.3","d":0.125,"e":7}`, v)
+		assertMarshalAllVM(t, "big number", `{"a":1.50,"b":0.3333,"c":"1.3","d":0.12500,"e":7}`, v, json.FloatPrecision(5))
+		assertMarshalAllVM(t, "only field", `{"a":2.00}`, &struct {
+			A *big.Float `json:"a,precision=2"`
+		}{A: big.NewFloat(2)})
+	})
+	t.Run("non-terminating rat", func(t *testing.T) {
+		// rounded to 20 digits after the decimal point unless the precision is specified
+		assertMarshalAllVM(t, "big number", `{"a":0.66666666666666666667,"b":0.142857}`, &struct {
+			A *big.Rat `json:"a"`
+			B *big.Rat `json:"b,precision=6"`
+		}{A: big.NewRat(2, 3), B: big.NewRat(1, 7)})
+	})
+	t.Run("infinity", func(t *testing.T) {
+		if _, err := json.Marshal(new(big.Float).SetInf(false)); err == nil {
+			t.Fatal("expected error")
+		}
+		got, err := json.MarshalWithOption(new(big.Float).SetInf(true), json.NonFiniteFloat(json.NonFiniteFloatNull))
+		assertErr(t, err)
+		assertEq(t, "big number", `null`, string(got))
+	})
+	t.Run("string", func(t *testing.T) {
+		type T struct {
+			A *big.Int   `json:"a,string"`
+			B *big.Float `json:"b,string"`
+			C *big.Rat   `json:"c,string"`
+			D big.Int    `json:"d,string"`
+			E *big.Int   `json:"e,string"`
+		}
+		v := T{A: big.NewInt(9), B: big.NewFloat(1.5), C: big.NewRat(1, 4), D: *big.NewInt(-3)}
+		got, err := json.Marshal(&v)
+		assertErr(t, err)
+		assertEq(t, "big number", `{"a":"9","b":"1.5","c":"0.25","d":"-3","e":null}`, string(got))
+		indented, err := json.MarshalIndent(&v, "", "")
+		assertErr(t, err)
+		assertEq(t, "big number", "{\n\"a\": \"9\",\n\"b\": \"1.5\",\n\"c\": \"0.25\",\n\"d\": \"-3\",\n\"e\": null\n}", string(indented))
+		var decoded T
+		assertErr(t, json.Unmarshal(got, &decoded))
+		if decoded.A.Cmp(v.A) != 0 || decoded.B.Cmp(v.B) != 0 || decoded.C.Cmp(v.C) != 0 || decoded.D.Cmp(&v.D) != 0 || decoded.E != nil {
+			t.Errorf("failed to round-trip: %+v", decoded)
+		}
+	})
+}
+
+func TestEncodeOrderedObject(t *testing.T) {
+	obj := json.OrderedObject{
+		{Key: "z", Value: 1},
+		{Key: "a", Value: []interface{}{json.OrderedObject{{Key: "y", Value: true}, {Key: "b", Value: nil}}}},
+		{Key: "<m>", Value: "s"},
+	}
+	t.Run("Marshal", func(t *testing.T) {
+		got, err := json.Marshal(obj)
+		assertErr(t, err)
+		assertEq(t, "ordered object", `{"z":1,"a":[{"y":true,"b":null}],"\u003cm\u003e":"s"}`, string(got))
+	})
+	t.Run("MarshalIndent", func(t *testing.T) {
+		got, err := json.MarshalIndent(json.OrderedObject{{Key: "b", Value: 1}, {Key: "a", Value: "x"}}, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "ordered object", "{\n  \"b\": 1,\n  \"a\": \"x\"\n}", string(got))
+	})
+	t.Run("field", func(t *testing.T) {
+		type T struct {
+			A json.OrderedObject  `json:"a"`
+			B *json.OrderedObject `json:"b"`
+			C json.OrderedObject  `json:"c,omitempty"`
+			D json.OrderedObject  `json:"d"`
+			E interface{}         `json:"e"`
+		}
+		got, err := json.Marshal(T{A: obj[:1], B: &json.OrderedObject{}, E: obj[2:]})
+		assertErr(t, err)
+		assertEq(t, "ordered object", `{"a":{"z":1},"b":{},"d":null,"e":{"\u003cm\u003e":"s"}}`, string(got))
+	})
+	t.Run("only pointer field", func(t *testing.T) {
+		o := json.OrderedObject{{Key: "b", Value: 1}, {Key: "a", Value: "x"}}
+		assertMarshalAllVM(t, "value", `{"O":{"b":1,"a":"x"}}`, struct{ O *json.OrderedObject }{&o})
+		assertMarshalAllVM(t, "pointer", `{"O":{"b":1,"a":"x"}}`, &struct{ O *json.OrderedObject }{&o})
+		assertMarshalAllVM(t, "nil", `{"O":null}`, struct{ O *json.OrderedObject }{})
+		assertMarshalAllVM(t, "omitempty", `{"o":{"b":1,"a":"x"}}`, struct {
+			O *json.OrderedObject `json:"o,omitempty"`
+		}{&o})
+		assertMarshalAllVM(t, "omitempty nil", `{}`, struct {
+			O *json.OrderedObject `json:"o,omitempty"`
+		}{})
+	})
+	t.Run("Set and Delete", func(t *testing.T) {
+		var o json.OrderedObject
+		o.Set("b", 1)
+		o.Set("a", 2)
+		o.Set("b", 3)
+		o.Delete("a")
+		o.Set("c", 4)
+		got, err := json.Marshal(o)
+		assertErr(t, err)
+		assertEq(t, "ordered object", `{"b":3,"c":4}`, string(got))
+	})
+}
+
+func TestEncodeMapKeyOrder(t *testing.T) {
+	v := map[string]int{"item10": 1, "item2": 2, "item1": 3}
+	t.Run("NaturalMapKeyOrder", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.NaturalMapKeyOrder())
+		assertErr(t, err)
+		assertEq(t, "natural order", `{"item1":3,"item2":2,"item10":1}`, string(got))
+	})
+	t.Run("NumericMapKeyOrder", func(t *testing.T) {
+		got, err := json.MarshalWithOption(map[int]string{10: "a", 9: "b", -1: "c"}, json.NumericMapKeyOrder())
+		assertErr(t, err)
+		assertEq(t, "numeric order", `{"-1":"c","9":"b","10":"a"}`, string(got))
+	})
+	t.Run("MapKeyOrder", func(t *testing.T) {
+		got, err := json.MarshalIndentWithOption(v, "", " ", json.MapKeyOrder(func(a, b string) bool { return a > b }))
+		assertErr(t, err)
+		assertEq(t, "custom order", "{\n \"item2\": 2,\n \"item10\": 1,\n \"item1\": 3\n}", string(got))
+	})
+}
+
+func TestEncodeTimeFormat(t *testing.T) {
+	tm := time.Date(2021, 3, 4, 5, 6, 7, 800000000, time.UTC)
+	type T struct {
+		A time.Time  `json:"a"`
+		B time.Time  `json:"b,format=unixmilli"`
+		C *time.Time `json:"c,omitempty,format=unix"`
+		D *time.Time `json:"d,omitempty"`
+		E time.Time  `json:"e,format=RFC1123"`
+	}
+	v := T{A: tm, B: tm, C: &tm, E: tm}
+	t.Run("default", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		expected, err := stdjson.Marshal(tm)
+		assertErr(t, err)
+		assertEq(t, "time", `{"a":`+string(expected)+`,"b":1614834367800,"c":1614834367,"e":"Thu, 04 Mar 2021 05:06:07 UTC"}`, string(got))
+	})
+	t.Run("TimeFormat", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.TimeFormat("unixnano"))
+		assertErr(t, err)
+		assertEq(t, "time", `{"a":1614834367800000000,"b":1614834367800,"c":1614834367,"e":"Thu, 04 Mar 2021 05:06:07 UTC"}`, string(got))
+	})
+	t.Run("layout", func(t *testing.T) {
+		got, err := json.MarshalWithOption([]interface{}{tm, &tm, (*time.Time)(nil)}, json.TimeFormat("2006-01-02 <15:04>"))
+		assertErr(t, err)
+		assertEq(t, "time", `["2021-03-04 \u003c05:06\u003e","2021-03-04 \u003c05:06\u003e",null]`, string(got))
+	})
+	t.Run("MarshalIndent", func(t *testing.T) {
+		got, err := json.MarshalIndentWithOption(struct {
+			A time.Time `json:"a,format=unixmicro"`
+		}{A: time.Unix(-1, 500000000)}, "", " ")
+		assertErr(t, err)
+		assertEq(t, "time", "{\n \"a\": -500000\n}", string(got))
+	})
+	t.Run("out of range", func(t *testing.T) {
+		_, err := json.Marshal(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("only pointer field", func(t *testing.T) {
+		ptm := &tm
+		type Inner struct {
+			T *time.Time `json:"t"`
+		}
+		assertMarshalAllVM(t, "value", `{"T":"2021-03-04T05:06:07.8Z"}`, struct{ T *time.Time }{&tm})
+		assertMarshalAllVM(t, "pointer", `{"T":"2021-03-04T05:06:07.8Z"}`, &struct{ T *time.Time }{&tm})
+		assertMarshalAllVM(t, "nil", `{"T":null}`, struct{ T *time.Time }{})
+		assertMarshalAllVM(t, "double pointer", `{"T":"2021-03-04T05:06:07.8Z"}`, struct{ T **time.Time }{&ptm})
+		assertMarshalAllVM(t, "nested", `{"A":{"t":"2021-03-04T05:06:07.8Z"}}`, struct{ A Inner }{Inner{&tm}})
+		assertMarshalAllVM(t, "format", `{"t":1614834367}`, struct {
+			T *time.Time `json:"t,format=unix"`
+		}{&tm})
+		assertMarshalAllVM(t, "TimeFormat", `{"T":1614834367800}`, struct{ T *time.Time }{&tm}, json.TimeFormat("unixmilli"))
+		assertMarshalAllVM(t, "omitempty", `{"t":"2021-03-04T05:06:07.8Z"}`, struct {
+			T *time.Time `json:"t,omitempty"`
+		}{&tm})
+		assertMarshalAllVM(t, "omitempty nil", `{}`, struct {
+			T *time.Time `json:"t,omitempty"`
+		}{})
+		var i interface{} = 1
+		assertMarshalAllVM(t, "interface", `{"I":1}`, struct{ I *interface{} }{&i})
+	})
+	t.Run("format with commas", func(t *testing.T) {
+		got, err := json.Marshal(struct {
+			T time.Time `json:"t,omitempty,format=2006,01,02"`
+		}{tm})
+		assertErr(t, err)
+		assertEq(t, "time", `{"t":"2021,03,04"}`, string(got))
+	})
+	t.Run("format struct tag", func(t *testing.T) {
+		// only the format option of the json tag is read, so that the tags of the other packages are not mixed up
+		got, err := json.Marshal(struct {
+			T time.Time `json:"t" format:"date-time"`
+		}{tm})
+		assertErr(t, err)
+		expected, err := stdjson.Marshal(tm)
+		assertErr(t, err)
+		assertEq(t, "time", `{"t":`+string(expected)+`}`, string(got))
+	})
+	t.Run("elements", func(t *testing.T) {
+		type U struct {
+			A []time.Time             `json:"a,format=2006-01-02"`
+			B *[]*time.Time           `json:"b,format=unix"`
+			C [1]time.Time            `json:"c,format=unixmilli"`
+			D [][]time.Time           `json:"d,format=unix"`
+			E struct{ T []time.Time } `json:"e,format=unix"`
+		}
+		u := U{
+			A: []time.Time{tm},
+			B: &[]*time.Time{&tm, nil},
+			C: [1]time.Time{tm},
+			D: [][]time.Time{{tm}},
+		}
+		u.E.T = []time.Time{time.Unix(0, 0).UTC()}
+		got, err := json.Marshal(u)
+		assertErr(t, err)
+		// the format does not apply to the fields of the nested struct
+		assertEq(t, "time", `{"a":["2021-03-04"],"b":[1614834367,null],"c":[1614834367800],"d":[[1614834367]],"e":{"T":["1970-01-01T00:00:00Z"]}}`, string(got))
+	})
+}
+
+// assertMarshalAllVM encodes v by the VMs for Marshal, colored Marshal, MarshalIndent and colored MarshalIndent,
+// and compares the results compacted to exp.
+func assertMarshalAllVM(t *testing.T, msg, exp string, v interface{}, opts ...json.EncodeOptionFunc) {
+	t.Helper()
+	color := json.Colorize(&json.ColorScheme{})
+	for _, vm := range []struct {
+		name    string
+		marshal func() ([]byte, error)
+	}{
+		{"normal", func() ([]byte, error) { return json.MarshalWithOption(v, opts...) }},
+		{"color", func() ([]byte, error) { return json.MarshalWithOption(v, append(opts, color)...) }},
+		{"indent", func() ([]byte, error) { return json.MarshalIndentWithOption(v, "", " ", opts...) }},
+		{"color-indent", func() ([]byte, error) { return json.MarshalIndentWithOption(v, "", " ", append(opts, color)...) }},
+	} {
+		got, err := vm.marshal()
+		assertErr(t, err)
+		var buf bytes.Buffer
+		assertErr(t, stdjson.Compact(&buf, got))
+		assertEq(t, msg+" by "+vm.name, exp, buf.String())
+	}
+}
+
+func TestEncodeBytesEncoding(t *testing.T) {
+	type T struct {
+		A []byte   `json:"a"`
+		B []byte   `json:"b,format=rawbase64url"`
+		C *[]byte  `json:"c,omitempty,format=hex"`
+		D []byte   `json:"d,format=array"`
+		E [3]byte  `json:"e"`
+		F [2]uint8 `json:"f,format=base64"`
+	}
+	b := []byte{0xfb, 0xff}
+	v := T{A: b, B: b, C: &b, D: b, E: [3]byte{1, 2, 3}, F: [2]uint8{0xfb, 0xff}}
+	t.Run("default", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "bytes", `{"a":"+/8=","b":"-_8","c":"fbff","d":[251,255],"e":[1,2,3],"f":"+/8="}`, string(got))
+	})
+	t.Run("BytesEncoding", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.BytesEncoding("hex"))
+		assertErr(t, err)
+		assertEq(t, "bytes", `{"a":"fbff","b":"-_8","c":"fbff","d":[251,255],"e":"010203","f":"+/8="}`, string(got))
+	})
+	t.Run("array", func(t *testing.T) {
+		got, err := json.MarshalWithOption([]interface{}{b, []byte{}, []byte(nil)}, json.BytesEncoding("array"))
+		assertErr(t, err)
+		assertEq(t, "bytes", `[[251,255],[],null]`, string(got))
+	})
+	t.Run("MarshalIndent", func(t *testing.T) {
+		got, err := json.MarshalIndentWithOption(struct {
+			A []byte `json:"a,format=array"`
+		}{A: []byte{1, 2}}, "", " ")
+		assertErr(t, err)
+		assertEq(t, "bytes", "{\n \"a\": [\n  1,\n  2\n ]\n}", string(got))
+	})
+}
+
+func TestEncodeNilAsEmpty(t *testing.T) {
+	type Inner struct {
+		S []int
+		M map[string]int
+	}
+	type T struct {
+		A []string       `json:"a,nilasempty"`
+		B map[string]int `json:"b,nilasempty"`
+		C [][]int        `json:"c,nilasempty"`
+		D []string       `json:"d"`
+		E *[]string      `json:"e,nilasempty"`
+		F []byte         `json:"f,nilasempty"`
+		G interface{}    `json:"g"`
+		H Inner          `json:"h,nilasempty"`
+		I []string       `json:"i,omitempty,nilasempty"`
+	}
+	v := T{C: [][]int{nil}, G: []int(nil)}
+	t.Run("tag", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "nil", `{"a":[],"b":{},"c":[[]],"d":null,"e":null,"f":"","g":null,"h":{"S":[],"M":{}}}`, string(got))
+	})
+	t.Run("NilAsEmpty", func(t *testing.T) {
+		got, err := json.MarshalWithOption(&v, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "nil", `{"a":[],"b":{},"c":[[]],"d":[],"e":null,"f":"","g":[],"h":{"S":[],"M":{}}}`, string(got))
+	})
+	t.Run("interface", func(t *testing.T) {
+		got, err := json.MarshalWithOption([]interface{}{[]int(nil), map[int]int(nil), []byte(nil), (*[]int)(nil)}, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "nil", `[[],{},"",null]`, string(got))
+	})
+	t.Run("MarshalIndent", func(t *testing.T) {
+		got, err := json.MarshalIndentWithOption(struct {
+			A []int
+			B map[string]int
+		}{}, "", " ", json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "nil", "{\n \"A\": [],\n \"B\": {}\n}", string(got))
+	})
+}
+
+func TestEncodeStringEscape(t *testing.T) {
+	v := "<a&b> \u2028\u2029 é😀 \"\\\n\x01\xff"
+	tests := []struct {
+		name     string
+		policy   json.StringEscapePolicy
+		expected string
+	}{
+		{"HTML", json.StringEscapeHTML, `"\u003ca\u0026b\u003e \u2028\u2029 é😀 \"\\\n\u0001\ufffd"`},
+		{"Minimal", json.StringEscapeMinimal, "\"<a&b> \u2028\u2029 é😀 \\\"\\\\\\n\\u0001\\ufffd\""},
+		{"ASCII", json.StringEscapeASCII, `"<a&b> \u2028\u2029 \u00e9\ud83d\ude00 \"\\\n\u0001\ufffd"`},
+		{"JavaScript", json.StringEscapeJavaScript, `"<a&b> \u2028\u2029 é😀 \"\\\n\u0001\ufffd"`},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.MarshalWithOption(v, json.StringEscape(tc.policy))
+			assertErr(t, err)
+			assertEq(t, "string", tc.expected, string(got))
+
+			// the SWAR fast path is used for long strings
+			long := strings.Repeat("x", 16)
+			got, err = json.MarshalWithOption(long+v, json.StringEscape(tc.policy))
+			assertErr(t, err)
+			assertEq(t, "long string", `"`+long+tc.expected[1:], string(got))
+		})
+	}
+	t.Run("Encoder", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		assertErr(t, enc.EncodeWithOption([]string{"<é>"}, json.StringEscape(json.StringEscapeHTML)))
+		assertErr(t, enc.EncodeWithOption([]string{"<é>"}, json.StringEscape(json.StringEscapeASCII)))
+		assertEq(t, "encoder", "[\"\\u003cé\\u003e\"]\n[\"<\\u00e9>\"]\n", buf.String())
+	})
+	t.Run("struct key", func(t *testing.T) {
+		type T struct {
+			Größe  int    `json:"größe"`
+			Name   string `json:"名前"`
+			Plain  bool   `json:"a<b"`
+			Nested struct {
+				Été int
+			} `json:"nested"`
+		}
+		v := &T{Größe: 1, Name: "é"}
+		assertMarshalAllVM(t, "ASCII", `{"gr\u00f6\u00dfe":1,"\u540d\u524d":"\u00e9","a<b":false,"nested":{"\u00c9t\u00e9":0}}`, v, json.StringEscape(json.StringEscapeASCII))
+		assertMarshalAllVM(t, "ASCII interface", `[{"gr\u00f6\u00dfe":1,"\u540d\u524d":"\u00e9","a<b":false,"nested":{"\u00c9t\u00e9":0}}]`, []interface{}{v}, json.StringEscape(json.StringEscapeASCII))
+		assertMarshalAllVM(t, "HTML", `{"größe":1,"名前":"é","a\u003cb":false,"nested":{"Été":0}}`, v)
+		assertMarshalAllVM(t, "Minimal", `{"größe":1,"名前":"é","a<b":false,"nested":{"Été":0}}`, v, json.StringEscape(json.StringEscapeMinimal))
+	})
+}
+
+type chanTestRowIter func() (int, bool)
+
+func TestEncodeChan(t *testing.T) {
+	newChan := func(vs ...int) chan int {
+		ch := make(chan int, len(vs))
+		for _, v := range vs {
+			ch <- v
+		}
+		close(ch)
+		return ch
+	}
+	t.Run("chan", func(t *testing.T) {
+		got, err := json.Marshal(newChan(1, 2, 3))
+		assertErr(t, err)
+		assertEq(t, "chan", `[1,2,3]`, string(got))
+	})
+	t.Run("field", func(t *testing.T) {
+		got, err := json.Marshal(struct {
+			A int
+			B <-chan int
+			C chan int
+			D chan int
+		}{A: 1, B: newChan(2, 3), C: newChan()})
+		assertErr(t, err)
+		assertEq(t, "chan", `{"A":1,"B":[2,3],"C":[],"D":null}`, string(got))
+	})
+	t.Run("slice", func(t *testing.T) {
+		got, err := json.Marshal([]interface{}{[]chan int{newChan(1), nil}, newChan(2)})
+		assertErr(t, err)
+		assertEq(t, "chan", `[[[1],null],[2]]`, string(got))
+	})
+	t.Run("MarshalIndent", func(t *testing.T) {
+		got, err := json.MarshalIndent(struct{ A chan int }{newChan(1, 2)}, "", " ")
+		assertErr(t, err)
+		assertEq(t, "chan", "{\n \"A\": [\n  1,\n  2\n ]\n}", string(got))
+	})
+	t.Run("iterator", func(t *testing.T) {
+		assertErr(t, json.RegisterIterator(chanTestRowIter(nil)))
+		n := 0
+		iter := chanTestRowIter(func() (int, bool) {
+			n++
+			return n, n <= 3
+		})
+		got, err := json.Marshal(struct{ A chanTestRowIter }{iter})
+		assertErr(t, err)
+		assertEq(t, "iterator", `{"A":[1,2,3]}`, string(got))
+		if err := json.RegisterIterator(func() int { return 0 }); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := json.Marshal(make(chan<- int)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := json.MarshalContext(ctx, make(chan int)); err != context.Canceled {
+			t.Fatalf("expected context.Canceled but got %v", err)
+		}
+	})
+}
+
+type parallelTestErrorValue int
+
+func (v parallelTestErrorValue) MarshalJSON() ([]byte, error) {
+	if v < 0 {
+		return nil, fmt.Errorf("negative value %d", v)
+	}
+	return []byte(strconv.Itoa(int(v))), nil
+}
+
+func TestEncodeParallel(t *testing.T) {
+	type T struct {
+		ID   int
+		Name string
+		Tags []string
+		Ptr  *int
+	}
+	values := make([]T, 1000)
+	for i := range values {
+		n := i
+		values[i] = T{ID: i, Name: fmt.Sprintf("n<%d>", i), Tags: []string{"a", "b"}, Ptr: &n}
+	}
+	t.Run("Marshal", func(t *testing.T) {
+		expected, err := json.Marshal(values)
+		assertErr(t, err)
+		for _, workers := range []int{0, 1, 3, 64, 2000} {
+			got, err := json.MarshalWithOption(values, json.Parallel(workers))
+			assertErr(t, err)
+			assertEq(t, "slice", string(expected), string(got))
+			got, err = json.MarshalWithOption(&values, json.Parallel(workers))
+			assertErr(t, err)
+			assertEq(t, "pointer", string(expected), string(got))
+		}
+	})
+	t.Run("MarshalIndent", func(t *testing.T) {
+		expected, err := json.MarshalIndent(values[:10], ">", "  ")
+		assertErr(t, err)
+		got, err := json.MarshalIndentWithOption(values[:10], ">", "  ", json.Parallel(3))
+		assertErr(t, err)
+		assertEq(t, "indent", string(expected), string(got))
+	})
+	t.Run("Encoder", func(t *testing.T) {
+		var expected, got bytes.Buffer
+		enc := json.NewEncoder(&expected)
+		enc.SetIndent("", "\t")
+		assertErr(t, enc.Encode(values[:10]))
+		enc = json.NewEncoder(&got)
+		enc.SetIndent("", "\t")
+		assertErr(t, enc.EncodeWithOption(values[:10], json.Parallel(4)))
+		assertEq(t, "encoder", expected.String(), got.String())
+	})
+	t.Run("flush threshold", func(t *testing.T) {
+		expected, err := json.Marshal(values)
+		assertErr(t, err)
+		var w recordWriter
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(64)
+		assertErr(t, enc.EncodeWithOption(values, json.Parallel(4)))
+		assertEq(t, "output", string(expected)+"\n", w.String())
+		assertEq(t, "writes", 1, len(w.sizes))
+	})
+	t.Run("error", func(t *testing.T) {
+		v := make([]parallelTestErrorValue, 100)
+		v[30], v[80] = -30, -80
+		_, err := json.MarshalWithOption(v, json.Parallel(4))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if !strings.Contains(err.Error(), "negative value -30") {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package json
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json/internal/encoder"
+)
+
+// tokenFrame is an object or an array opened by the token-level API of Encoder.
+type tokenFrame struct {
+	delim  Delim
+	count  int  // number of the members or elements written
+	hasKey bool // a key of the object is written and it is waiting for the value
+}
+
+// WriteToken writes a token to the stream.
+// Delim '{', '}', '[' and ']' open and close objects and arrays, a string written where an object key is expected is the key,
+// and any other token ( bool, float64, Number, string, nil or any value accepted by Encode ) is written as a value.
+//
+// Commas, colons and the indentation configured by SetIndent are inserted automatically,
+// and nesting errors such as closing an array with '}' or writing an object value without a key are reported.
+// Encode can be interleaved with WriteToken to write a subvalue at once.
+// When a top-level value is completed, a newline character is written as Encode does.
+//
+// The tokens are written to the stream as soon as they are given, so the stream has a partially written document until it is completed.
+// If a value cannot be encoded, nothing is written for it ( unless SetFlushThreshold has flushed a part of it )
+// and another value can be written in its place.
+func (e *Encoder) WriteToken(t Token) error {
+	switch v := t.(type) {
+	case Delim:
+		switch v {
+		case '{', '[':
+			return e.beginToken(v)
+		case '}', ']':
+			return e.endToken(v)
+		}
+		return fmt.Errorf("json: invalid delimiter %q", rune(v))
+	case string:
+		if frame := e.topToken(); frame != nil && frame.delim == '{' && !frame.hasKey {
+			return e.Key(v)
+		}
+	}
+	return e.Encode(t)
+}
+
+// BeginObject writes '{' to start an object.
+func (e *Encoder) BeginObject() error {
+	return e.beginToken('{')
+}
+
+// EndObject writes '}' to end the object started by BeginObject.
+func (e *Encoder) EndObject() error {
+	return e.endToken('}')
+}
+
+// BeginArray writes '[' to start an array.
+func (e *Encoder) BeginArray() error {
+	return e.beginToken('[')
+}
+
+// EndArray writes ']' to end the array started by BeginArray.
+func (e *Encoder) EndArray() error {
+	return e.endToken(']')
+}
+
+// Key writes an object key. The next token or value written is the value for the key.
+func (e *Encoder) Key(key string) error {
+	frame := e.topToken()
+	if frame == nil || frame.delim != '{' {
+		return fmt.Errorf("json: cannot write key %q outside of an object", key)
+	}
+	if frame.hasKey {
+		return fmt.Errorf("json: cannot write key %q before the value for the previous key", key)
+	}
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = 0
+	if e.enabledHTMLEscape {
+		ctx.Option.Flag |= encoder.HTMLEscapeOption
+	}
+	b := e.appendTokenSeparator(e.tokenBuf[:0], frame)
+	frame.count++
+	b = encoder.AppendString(ctx, b, key)
+	b = append(b, ':')
+	if e.enabledIndent {
+		b = append(b, ' ')
+	}
+	encoder.ReleaseRuntimeContext(ctx)
+	e.tokenBuf = b
+	frame.hasKey = true
+	_, err := e.w.Write(b)
+	return err
+}
+
+func (e *Encoder) topToken() *tokenFrame {
+	if len(e.tokens) == 0 {
+		return nil
+	}
+	return &e.tokens[len(e.tokens)-1]
+}
+
+func (e *Encoder) beginToken(delim Delim) error {
+	b, err := e.appendValueSeparator(e.tokenBuf[:0])
+	if err != nil {
+		return err
+	}
+	b = append(b, byte(delim))
+	e.tokenBuf = b
+	e.valueWritten()
+	e.tokens = append(e.tokens, tokenFrame{delim: delim})
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *Encoder) endToken(delim Delim) error {
+	frame := e.topToken()
+	if frame == nil {
+		return fmt.Errorf("json: unexpected %q without the beginning", rune(delim))
+	}
+	if (frame.delim == '{' && delim != '}') || (frame.delim == '[' && delim != ']') {
+		return fmt.Errorf("json: cannot close %q with %q", rune(frame.delim), rune(delim))
+	}
+	if frame.hasKey {
+		return fmt.Errorf("json: cannot close object before the value for the last key")
+	}
+	count := frame.count
+	e.tokens = e.tokens[:len(e.tokens)-1]
+	b := e.tokenBuf[:0]
+	if e.enabledIndent && count > 0 {
+		b = append(b, '\n')
+		b = append(b, e.tokenIndentPrefix()...)
+	}
+	b = append(b, byte(delim))
+	if len(e.tokens) == 0 {
+		b = append(b, '\n')
+	}
+	e.tokenBuf = b
+	_, err := e.w.Write(b)
+	return err
+}
+
+// appendValueSeparator appends the comma and the indentation required before the next value.
+// The frame is not updated until the value is written by valueWritten.
+func (e *Encoder) appendValueSeparator(b []byte) ([]byte, error) {
+	frame := e.topToken()
+	if frame == nil {
+		return b, nil
+	}
+	if frame.delim == '{' {
+		if !frame.hasKey {
+			return nil, fmt.Errorf("json: cannot write an object value without a key")
+		}
+		return b, nil
+	}
+	return e.appendTokenSeparator(b, frame), nil
+}
+
+// valueWritten updates the innermost frame after a value is written in it.
+func (e *Encoder) valueWritten() {
+	frame := e.topToken()
+	if frame == nil {
+		return
+	}
+	if frame.delim == '{' {
+		frame.hasKey = false
+		return
+	}
+	frame.count++
+}
+
+func (e *Encoder) appendTokenSeparator(b []byte, frame *tokenFrame) []byte {
+	if frame.count > 0 {
+		b = append(b, ',')
+	}
+	if e.enabledIndent {
+		b = append(b, '\n')
+		b = append(b, e.tokenIndentPrefix()...)
+	}
+	return b
+}
+
+// tokenIndentPrefix returns the indentation of the current nesting level.
+func (e *Encoder) tokenIndentPrefix() string {
+	return e.prefix + strings.Repeat(e.indentStr, len(e.tokens))
+}
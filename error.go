@@ -19,6 +19,9 @@ type InvalidUnmarshalError = errors.InvalidUnmarshalError
 // A MarshalerError represents an error from calling a MarshalJSON or MarshalText method.
 type MarshalerError = errors.MarshalerError
 
+// A LineError describes an error in a line read by LineReader.
+type LineError = errors.LineError
+
 // A SyntaxError is a description of a JSON syntax error.
 type SyntaxError = errors.SyntaxError
 
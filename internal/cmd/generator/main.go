@@ -79,6 +79,14 @@ func (t OpType) CodeType() CodeType {
     return CodeSliceHead
   case OpSliceElem:
     return CodeSliceElem
+  case OpOrderedObject, OpOrderedObjectPtr:
+    return CodeSliceHead
+  case OpOrderedObjectElem:
+    return CodeSliceElem
+  case OpChan, OpChanPtr:
+    return CodeSliceHead
+  case OpChanElem:
+    return CodeSliceElem
   case OpMap, OpMapPtr:
     return CodeMapHead
   case OpMapKey:
@@ -201,6 +209,17 @@ func (t OpType) FieldToOmitEmptyField() OpType {
 		createOpType("RecursivePtr", "Op"),
 		createOpType("RecursiveEnd", "Op"),
 		createOpType("InterfaceEnd", "Op"),
+		createOpType("OrderedObject", "SliceHead"),
+		createOpType("OrderedObjectPtr", "SliceHead"),
+		createOpType("OrderedObjectKey", "Op"),
+		createOpType("OrderedObjectElem", "SliceElem"),
+		createOpType("OrderedObjectEnd", "Op"),
+		createOpType("Time", "Op"),
+		createOpType("TimePtr", "Op"),
+		createOpType("Chan", "SliceHead"),
+		createOpType("ChanPtr", "SliceHead"),
+		createOpType("ChanElem", "SliceElem"),
+		createOpType("ChanEnd", "Op"),
 	}
 	for _, typ := range primitiveTypesUpper {
 		typ := typ
@@ -14,11 +14,11 @@ type arrayDecoder struct {
 	alen         int
 	structName   string
 	fieldName    string
-	zeroValue    unsafe.Pointer
+	zeroValue    unsafe.Pointer // pointer to the zero value of elemType
 }
 
 func newArrayDecoder(dec Decoder, elemType *runtime.Type, alen int, structName, fieldName string) *arrayDecoder {
-	zeroValue := *(*unsafe.Pointer)(unsafe_New(elemType))
+	zeroValue := unsafe_New(elemType)
 	return &arrayDecoder{
 		valueDecoder: dec,
 		elemType:     elemType,
@@ -49,7 +49,7 @@ func (d *arrayDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 			s.cursor++
 			if s.skipWhiteSpace() == ']' {
 				for idx < d.alen {
-					*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
+					typedmemmove(d.elemType, unsafe.Pointer(uintptr(p)+uintptr(idx)*d.size), d.zeroValue)
 					idx++
 				}
 				s.cursor++
@@ -69,7 +69,7 @@ func (d *arrayDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 				switch s.skipWhiteSpace() {
 				case ']':
 					for idx < d.alen {
-						*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
+						typedmemmove(d.elemType, unsafe.Pointer(uintptr(p)+uintptr(idx)*d.size), d.zeroValue)
 						idx++
 					}
 					s.cursor++
@@ -105,11 +105,11 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 	buf := ctx.Buf
 	depth++
 	if depth > maxDecodeNestingDepth {
-		return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+		return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 	}
 
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 			continue
@@ -123,9 +123,9 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 			idx := 0
 			cursor++
 			cursor = skipWhiteSpace(buf, cursor)
-			if buf[cursor] == ']' {
+			if bufChar(buf, cursor) == ']' {
 				for idx < d.alen {
-					*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
+					typedmemmove(d.elemType, unsafe.Pointer(uintptr(p)+uintptr(idx)*d.size), d.zeroValue)
 					idx++
 				}
 				cursor++
@@ -147,10 +147,10 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 				}
 				idx++
 				cursor = skipWhiteSpace(buf, cursor)
-				switch buf[cursor] {
+				switch bufChar(buf, cursor) {
 				case ']':
 					for idx < d.alen {
-						*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
+						typedmemmove(d.elemType, unsafe.Pointer(uintptr(p)+uintptr(idx)*d.size), d.zeroValue)
 						idx++
 					}
 					cursor++
@@ -159,7 +159,7 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 					cursor++
 					continue
 				default:
-					return 0, errors.ErrInvalidCharacter(buf[cursor], "array", cursor)
+					return 0, errors.ErrInvalidCharacter(bufChar(buf, cursor), "array", cursor)
 				}
 			}
 		default:
@@ -0,0 +1,120 @@
+package decoder
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+var (
+	bigIntType   = runtime.Type2RType(reflect.TypeOf(big.Int{}))
+	bigFloatType = runtime.Type2RType(reflect.TypeOf(big.Float{}))
+	bigRatType   = runtime.Type2RType(reflect.TypeOf(big.Rat{}))
+)
+
+func isBigNumberType(typ *runtime.Type) bool {
+	return typ == bigIntType || typ == bigFloatType || typ == bigRatType
+}
+
+// bigNumberDecoder decodes a bare JSON number literal without going through float64,
+// so that the value keeps all digits of the source.
+type bigNumberDecoder struct {
+	floatDecoder *floatDecoder
+	typ          *runtime.Type
+	op           func(unsafe.Pointer, string) bool
+	structName   string
+	fieldName    string
+}
+
+func newBigNumberDecoder(typ *runtime.Type, structName, fieldName string, op func(unsafe.Pointer, string) bool) *bigNumberDecoder {
+	return &bigNumberDecoder{
+		floatDecoder: newFloatDecoder(structName, fieldName, nil),
+		typ:          typ,
+		op:           op,
+		structName:   structName,
+		fieldName:    fieldName,
+	}
+}
+
+func compileBigNumber(typ *runtime.Type, structName, fieldName string) (Decoder, error) {
+	switch typ {
+	case bigIntType:
+		return newBigNumberDecoder(typ, structName, fieldName, func(p unsafe.Pointer, v string) bool {
+			_, ok := (*big.Int)(p).SetString(v, 10)
+			return ok
+		}), nil
+	case bigFloatType:
+		return newBigNumberDecoder(typ, structName, fieldName, func(p unsafe.Pointer, v string) bool {
+			f := (*big.Float)(p)
+			if f.Prec() == 0 {
+				// about 3.3 bits are needed per decimal digit, so this is enough to keep every digit of the literal.
+				f.SetPrec(bigFloatPrec(len(v)))
+			}
+			_, ok := f.SetString(v)
+			return ok
+		}), nil
+	case bigRatType:
+		return newBigNumberDecoder(typ, structName, fieldName, func(p unsafe.Pointer, v string) bool {
+			_, ok := (*big.Rat)(p).SetString(v)
+			return ok
+		}), nil
+	}
+	return nil, fmt.Errorf("json: unsupported big number type %s", typ)
+}
+
+func bigFloatPrec(digits int) uint {
+	prec := uint(digits) * 4
+	if prec < 64 {
+		return 64
+	}
+	return prec
+}
+
+func (d *bigNumberDecoder) typeError(v string, offset int64) *errors.UnmarshalTypeError {
+	return &errors.UnmarshalTypeError{
+		Value:  fmt.Sprintf("number %s", v),
+		Type:   runtime.RType2Type(d.typ),
+		Struct: d.structName,
+		Field:  d.fieldName,
+		Offset: offset,
+	}
+}
+
+func (d *bigNumberDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	bytes, err := d.floatDecoder.decodeStreamByte(s)
+	if err != nil {
+		return err
+	}
+	if bytes == nil {
+		return nil
+	}
+	str := string(bytes)
+	if !d.op(p, str) {
+		return d.typeError(str, s.totalOffset())
+	}
+	return nil
+}
+
+func (d *bigNumberDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	bytes, c, err := d.floatDecoder.decodeByte(buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if bytes == nil {
+		return c, nil
+	}
+	cursor = c
+	if !validEndNumberChar[bufChar(buf, cursor)] {
+		return 0, errors.ErrUnexpectedEndOfJSON("number", cursor)
+	}
+	str := string(bytes)
+	if !d.op(p, str) {
+		return 0, d.typeError(str, cursor)
+	}
+	return cursor, nil
+}
@@ -52,7 +52,7 @@ ERROR:
 func (d *boolDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
 	buf := ctx.Buf
 	cursor = skipWhiteSpace(buf, cursor)
-	switch buf[cursor] {
+	switch bufChar(buf, cursor) {
 	case 't':
 		if err := validateTrue(buf, cursor); err != nil {
 			return 0, err
@@ -0,0 +1,186 @@
+package decoder
+
+// The bounded scanners validate a value without decoding it. Unlike the other scanners of this package,
+// they do not require nul at the end of buf and never read beyond len(buf).
+
+func skipBoundedWhiteSpace(buf []byte, cursor int) int {
+	for cursor < len(buf) && isWhiteSpace[buf[cursor]] {
+		cursor++
+	}
+	return cursor
+}
+
+func scanBoundedValue(buf []byte, cursor int, depth int64) (int, bool) {
+	cursor = skipBoundedWhiteSpace(buf, cursor)
+	if cursor == len(buf) {
+		return 0, false
+	}
+	switch buf[cursor] {
+	case '{':
+		return scanBoundedObject(buf, cursor, depth+1)
+	case '[':
+		return scanBoundedArray(buf, cursor, depth+1)
+	case '"':
+		return scanBoundedString(buf, cursor)
+	case 't':
+		return scanBoundedLiteral(buf, cursor, "true")
+	case 'f':
+		return scanBoundedLiteral(buf, cursor, "false")
+	case 'n':
+		return scanBoundedLiteral(buf, cursor, "null")
+	}
+	return scanBoundedNumber(buf, cursor)
+}
+
+func scanBoundedObject(buf []byte, cursor int, depth int64) (int, bool) {
+	if depth > maxDecodeNestingDepth {
+		return 0, false
+	}
+	cursor = skipBoundedWhiteSpace(buf, cursor+1)
+	if cursor < len(buf) && buf[cursor] == '}' {
+		return cursor + 1, true
+	}
+	for {
+		if cursor == len(buf) || buf[cursor] != '"' {
+			return 0, false
+		}
+		c, ok := scanBoundedString(buf, cursor)
+		if !ok {
+			return 0, false
+		}
+		cursor = skipBoundedWhiteSpace(buf, c)
+		if cursor == len(buf) || buf[cursor] != ':' {
+			return 0, false
+		}
+		cursor, ok = scanBoundedValue(buf, cursor+1, depth)
+		if !ok {
+			return 0, false
+		}
+		cursor = skipBoundedWhiteSpace(buf, cursor)
+		if cursor == len(buf) {
+			return 0, false
+		}
+		switch buf[cursor] {
+		case '}':
+			return cursor + 1, true
+		case ',':
+			cursor = skipBoundedWhiteSpace(buf, cursor+1)
+		default:
+			return 0, false
+		}
+	}
+}
+
+func scanBoundedArray(buf []byte, cursor int, depth int64) (int, bool) {
+	if depth > maxDecodeNestingDepth {
+		return 0, false
+	}
+	cursor = skipBoundedWhiteSpace(buf, cursor+1)
+	if cursor < len(buf) && buf[cursor] == ']' {
+		return cursor + 1, true
+	}
+	for {
+		c, ok := scanBoundedValue(buf, cursor, depth)
+		if !ok {
+			return 0, false
+		}
+		cursor = skipBoundedWhiteSpace(buf, c)
+		if cursor == len(buf) {
+			return 0, false
+		}
+		switch buf[cursor] {
+		case ']':
+			return cursor + 1, true
+		case ',':
+			cursor++
+		default:
+			return 0, false
+		}
+	}
+}
+
+func scanBoundedString(buf []byte, cursor int) (int, bool) {
+	for cursor++; cursor < len(buf); cursor++ {
+		switch c := buf[cursor]; {
+		case c == '"':
+			return cursor + 1, true
+		case c == '\\':
+			cursor++
+			if cursor == len(buf) {
+				return 0, false
+			}
+			switch buf[cursor] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			case 'u':
+				if cursor+4 >= len(buf) {
+					return 0, false
+				}
+				for _, h := range buf[cursor+1 : cursor+5] {
+					if !isHexChar(h) {
+						return 0, false
+					}
+				}
+				cursor += 4
+			default:
+				return 0, false
+			}
+		case c < 0x20:
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+func isHexChar(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func scanBoundedLiteral(buf []byte, cursor int, literal string) (int, bool) {
+	end := cursor + len(literal)
+	if end > len(buf) || string(buf[cursor:end]) != literal {
+		return 0, false
+	}
+	return end, true
+}
+
+func scanBoundedNumber(buf []byte, cursor int) (int, bool) {
+	if buf[cursor] == '-' {
+		cursor++
+	}
+	switch {
+	case cursor == len(buf):
+		return 0, false
+	case buf[cursor] == '0':
+		cursor++
+	case '1' <= buf[cursor] && buf[cursor] <= '9':
+		cursor = scanBoundedDigits(buf, cursor)
+	default:
+		return 0, false
+	}
+	if cursor < len(buf) && buf[cursor] == '.' {
+		c := scanBoundedDigits(buf, cursor+1)
+		if c == cursor+1 {
+			return 0, false
+		}
+		cursor = c
+	}
+	if cursor < len(buf) && (buf[cursor] == 'e' || buf[cursor] == 'E') {
+		cursor++
+		if cursor < len(buf) && (buf[cursor] == '+' || buf[cursor] == '-') {
+			cursor++
+		}
+		c := scanBoundedDigits(buf, cursor)
+		if c == cursor {
+			return 0, false
+		}
+		cursor = c
+	}
+	return cursor, true
+}
+
+func scanBoundedDigits(buf []byte, cursor int) int {
+	for cursor < len(buf) && '0' <= buf[cursor] && buf[cursor] <= '9' {
+		cursor++
+	}
+	return cursor
+}
@@ -1,7 +1,9 @@
 package decoder
 
 import (
-	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/errors"
@@ -11,7 +13,9 @@ import (
 type bytesDecoder struct {
 	typ           *runtime.Type
 	sliceDecoder  Decoder
+	arrayDecoder  Decoder
 	stringDecoder *stringDecoder
+	encoding      runtime.BytesEncoding
 	structName    string
 	fieldName     string
 }
@@ -34,12 +38,19 @@ func newBytesDecoder(typ *runtime.Type, structName string, fieldName string) *by
 	return &bytesDecoder{
 		typ:           typ,
 		sliceDecoder:  byteUnmarshalerSliceDecoder(typ, structName, fieldName),
+		arrayDecoder:  newSliceDecoder(newByteDecoder(typ, structName, fieldName), typ, 1, structName, fieldName),
 		stringDecoder: newStringDecoder(structName, fieldName),
 		structName:    structName,
 		fieldName:     fieldName,
 	}
 }
 
+func newByteDecoder(typ *runtime.Type, structName, fieldName string) *uintDecoder {
+	return newUintDecoder(typ, structName, fieldName, func(p unsafe.Pointer, v uint64) {
+		*(*uint8)(p) = uint8(v)
+	})
+}
+
 func (d *bytesDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
 	bytes, err := d.decodeStreamBinary(s, depth, p)
 	if err != nil {
@@ -49,13 +60,15 @@ func (d *bytesDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 		s.reset()
 		return nil
 	}
-	decodedLen := base64.StdEncoding.DecodedLen(len(bytes))
-	buf := make([]byte, decodedLen)
-	n, err := base64.StdEncoding.Decode(buf, bytes)
+	opt := bytesEncoding(d.encoding, s.Option)
+	if !opt.acceptsString() {
+		return d.typeError(s.totalOffset())
+	}
+	buf, err := decodeBytesString(bytes, opt)
 	if err != nil {
 		return err
 	}
-	*(*[]byte)(p) = buf[:n]
+	*(*[]byte)(p) = buf
 	s.reset()
 	return nil
 }
@@ -69,27 +82,31 @@ func (d *bytesDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 		return c, nil
 	}
 	cursor = c
-	decodedLen := base64.StdEncoding.DecodedLen(len(bytes))
-	b := make([]byte, decodedLen)
-	n, err := base64.StdEncoding.Decode(b, bytes)
+	opt := bytesEncoding(d.encoding, ctx.Option)
+	if !opt.acceptsString() {
+		return 0, d.typeError(cursor)
+	}
+	b, err := decodeBytesString(bytes, opt)
 	if err != nil {
 		return 0, err
 	}
-	*(*[]byte)(p) = b[:n]
+	*(*[]byte)(p) = b
 	return cursor, nil
 }
 
 func (d *bytesDecoder) decodeStreamBinary(s *Stream, depth int64, p unsafe.Pointer) ([]byte, error) {
 	c := s.skipWhiteSpace()
 	if c == '[' {
-		if d.sliceDecoder == nil {
-			return nil, &errors.UnmarshalTypeError{
-				Type:   runtime.RType2Type(d.typ),
-				Offset: s.totalOffset(),
-			}
+		switch {
+		case d.sliceDecoder != nil:
+			return nil, d.sliceDecoder.DecodeStream(s, depth, p)
+		case bytesEncoding(d.encoding, s.Option).acceptsNumberArray():
+			return nil, d.arrayDecoder.DecodeStream(s, depth, p)
+		}
+		return nil, &errors.UnmarshalTypeError{
+			Type:   runtime.RType2Type(d.typ),
+			Offset: s.totalOffset(),
 		}
-		err := d.sliceDecoder.DecodeStream(s, depth, p)
-		return nil, err
 	}
 	return d.stringDecoder.decodeStreamByte(s)
 }
@@ -97,14 +114,20 @@ func (d *bytesDecoder) decodeStreamBinary(s *Stream, depth int64, p unsafe.Point
 func (d *bytesDecoder) decodeBinary(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) ([]byte, int64, error) {
 	buf := ctx.Buf
 	cursor = skipWhiteSpace(buf, cursor)
-	if buf[cursor] == '[' {
-		if d.sliceDecoder == nil {
+	if bufChar(buf, cursor) == '[' {
+		var dec Decoder
+		switch {
+		case d.sliceDecoder != nil:
+			dec = d.sliceDecoder
+		case bytesEncoding(d.encoding, ctx.Option).acceptsNumberArray():
+			dec = d.arrayDecoder
+		default:
 			return nil, 0, &errors.UnmarshalTypeError{
 				Type:   runtime.RType2Type(d.typ),
 				Offset: cursor,
 			}
 		}
-		c, err := d.sliceDecoder.Decode(ctx, cursor, depth, p)
+		c, err := dec.Decode(ctx, cursor, depth, p)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -112,3 +135,148 @@ func (d *bytesDecoder) decodeBinary(ctx *RuntimeContext, cursor, depth int64, p
 	}
 	return d.stringDecoder.decodeByte(buf, cursor)
 }
+
+func (d *bytesDecoder) typeError(offset int64) *errors.UnmarshalTypeError {
+	return &errors.UnmarshalTypeError{
+		Value:  "string",
+		Type:   reflect.SliceOf(runtime.RType2Type(d.typ)),
+		Struct: d.structName,
+		Field:  d.fieldName,
+		Offset: offset,
+	}
+}
+
+// byteArrayDecoder decodes [N]byte value from JSON string encoded by BytesEncoding,
+// and decodes the other values by arrayDecoder.
+type byteArrayDecoder struct {
+	*arrayDecoder
+	stringDecoder *stringDecoder
+	encoding      runtime.BytesEncoding
+}
+
+func newByteArrayDecoder(dec *arrayDecoder) *byteArrayDecoder {
+	return &byteArrayDecoder{
+		arrayDecoder:  dec,
+		stringDecoder: newStringDecoder(dec.structName, dec.fieldName),
+	}
+}
+
+func (d *byteArrayDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	opt := bytesEncoding(d.encoding, s.Option)
+	if s.skipWhiteSpace() != '"' || !opt.acceptsString() {
+		return d.arrayDecoder.DecodeStream(s, depth, p)
+	}
+	src, err := d.stringDecoder.decodeStreamByte(s)
+	if err != nil {
+		return err
+	}
+	if err := d.copyBytes(src, opt, s.totalOffset(), p); err != nil {
+		return err
+	}
+	s.reset()
+	return nil
+}
+
+func (d *byteArrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	opt := bytesEncoding(d.encoding, ctx.Option)
+	cursor = skipWhiteSpace(ctx.Buf, cursor)
+	if bufChar(ctx.Buf, cursor) != '"' || !opt.acceptsString() {
+		return d.arrayDecoder.Decode(ctx, cursor, depth, p)
+	}
+	src, c, err := d.stringDecoder.decodeByte(ctx.Buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.copyBytes(src, opt, c, p); err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+func (d *byteArrayDecoder) copyBytes(src []byte, opt bytesDecodeOption, offset int64, p unsafe.Pointer) error {
+	b, err := decodeBytesString(src, opt)
+	if err != nil {
+		return err
+	}
+	if len(b) != d.alen {
+		return &errors.UnmarshalTypeError{
+			Value:  fmt.Sprintf("string of %d bytes", len(b)),
+			Type:   reflect.ArrayOf(d.alen, runtime.RType2Type(d.elemType)),
+			Struct: d.structName,
+			Field:  d.fieldName,
+			Offset: offset,
+		}
+	}
+	copy(*(*[]byte)(unsafe.Pointer(&sliceHeader{data: p, len: d.alen, cap: d.alen})), b)
+	return nil
+}
+
+// bytesDecodeOption is the encoding of []byte or [N]byte value resolved from the struct tag and the decode options.
+type bytesDecodeOption struct {
+	encoding runtime.BytesEncoding
+	any      bool
+}
+
+func bytesEncoding(enc runtime.BytesEncoding, opt *Option) bytesDecodeOption {
+	if enc == runtime.BytesEncodingDefault && (opt.Flags&BytesEncodingOption) != 0 {
+		enc = opt.BytesEncoding
+	}
+	return bytesDecodeOption{encoding: enc, any: (opt.Flags & AnyBytesEncodingOption) != 0}
+}
+
+func (o bytesDecodeOption) acceptsNumberArray() bool {
+	return o.any || o.encoding == runtime.BytesEncodingArray
+}
+
+func (o bytesDecodeOption) acceptsString() bool {
+	return o.any || o.encoding != runtime.BytesEncodingArray
+}
+
+// anyBytesEncodings is the order of encodings tried by AnyBytesEncodingOption
+// after the encoding specified by the struct tag or BytesEncodingOption fails.
+var anyBytesEncodings = []runtime.BytesEncoding{
+	runtime.BytesEncodingBase64,
+	runtime.BytesEncodingRawBase64,
+	runtime.BytesEncodingBase64URL,
+	runtime.BytesEncodingRawBase64URL,
+	runtime.BytesEncodingHex,
+}
+
+func decodeBytesString(src []byte, opt bytesDecodeOption) ([]byte, error) {
+	enc := opt.encoding
+	if enc == runtime.BytesEncodingArray {
+		// reached only with AnyBytesEncodingOption
+		enc = runtime.BytesEncodingBase64
+	}
+	b, err := decodeBytesWithEncoding(src, enc)
+	if err == nil || !opt.any {
+		return b, err
+	}
+	for _, other := range anyBytesEncodings {
+		if other == enc {
+			continue
+		}
+		if b, e := decodeBytesWithEncoding(src, other); e == nil {
+			return b, nil
+		}
+	}
+	return nil, err
+}
+
+func decodeBytesWithEncoding(src []byte, enc runtime.BytesEncoding) ([]byte, error) {
+	if enc == runtime.BytesEncodingHex {
+		b := make([]byte, hex.DecodedLen(len(src)))
+		n, err := hex.Decode(b, src)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+	encoding := enc.Base64()
+	b := make([]byte, encoding.DecodedLen(len(src)))
+	n, err := encoding.Decode(b, src)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
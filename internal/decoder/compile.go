@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"sync/atomic"
+	"sync"
 	"unicode"
 	"unsafe"
 
@@ -16,8 +16,10 @@ import (
 var (
 	jsonNumberType   = reflect.TypeOf(json.Number(""))
 	typeAddr         *runtime.TypeAddr
-	cachedDecoderMap unsafe.Pointer // map[uintptr]decoder
+	cachedDecoderMap runtime.TypeCache // Decoder for the types outside of typeAddr
 	cachedDecoder    []Decoder
+	cachedTypesMu    sync.Mutex
+	cachedTypes      = map[*runtime.Type]struct{}{}
 )
 
 func init() {
@@ -28,34 +30,43 @@ func init() {
 	cachedDecoder = make([]Decoder, typeAddr.AddrRange>>typeAddr.AddrShift)
 }
 
-func loadDecoderMap() map[uintptr]Decoder {
-	p := atomic.LoadPointer(&cachedDecoderMap)
-	return *(*map[uintptr]Decoder)(unsafe.Pointer(&p))
+// addCachedType records typ as the pointer type whose Decoder is stored in cachedDecoder.
+func addCachedType(typ *runtime.Type) {
+	cachedTypesMu.Lock()
+	cachedTypes[typ] = struct{}{}
+	cachedTypesMu.Unlock()
 }
 
-func storeDecoder(typ uintptr, dec Decoder, m map[uintptr]Decoder) {
-	newDecoderMap := make(map[uintptr]Decoder, len(m)+1)
-	newDecoderMap[typ] = dec
-
-	for k, v := range m {
-		newDecoderMap[k] = v
+// CachedTypes returns the pointer types whose Decoder is already compiled by CompileToGetDecoder.
+func CachedTypes() []*runtime.Type {
+	cachedTypesMu.Lock()
+	defer cachedTypesMu.Unlock()
+	types := make([]*runtime.Type, 0, len(cachedTypes))
+	for typ := range cachedTypes {
+		types = append(types, typ)
 	}
+	cachedDecoderMap.Range(func(typ *runtime.Type, _ interface{}) {
+		types = append(types, typ)
+	})
+	return types
+}
 
-	atomic.StorePointer(&cachedDecoderMap, *(*unsafe.Pointer)(unsafe.Pointer(&newDecoderMap)))
+// SetCacheLimit bounds the number of the Decoders cached for the types outside of the range of the type addresses,
+// such as the types created by reflect.StructOf. n <= 0 means unbounded.
+func SetCacheLimit(n int) {
+	cachedDecoderMap.SetLimit(n)
 }
 
-func compileToGetDecoderSlowPath(typeptr uintptr, typ *runtime.Type) (Decoder, error) {
-	decoderMap := loadDecoderMap()
-	if dec, exists := decoderMap[typeptr]; exists {
-		return dec, nil
+func compileToGetDecoderSlowPath(typ *runtime.Type) (Decoder, error) {
+	if dec, exists := cachedDecoderMap.Get(typ); exists {
+		return dec.(Decoder), nil
 	}
 
 	dec, err := compileHead(typ, map[uintptr]Decoder{})
 	if err != nil {
 		return nil, err
 	}
-	storeDecoder(typeptr, dec, decoderMap)
-	return dec, nil
+	return cachedDecoderMap.Store(typ, dec).(Decoder), nil
 }
 
 func compileHead(typ *runtime.Type, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
@@ -70,6 +81,12 @@ func compileHead(typ *runtime.Type, structTypeToDecoder map[uintptr]Decoder) (De
 
 func compile(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
 	switch {
+	case isBigNumberType(typ):
+		return compileBigNumber(typ, structName, fieldName)
+	case typ == orderedObjectType:
+		return compileOrderedObject(structName, fieldName)
+	case typ == timeType:
+		return compileTime(structName, fieldName)
 	case implementsUnmarshalJSONType(runtime.PtrTo(typ)):
 		return newUnmarshalJSONDecoder(runtime.PtrTo(typ), structName, fieldName), nil
 	case runtime.PtrTo(typ).Implements(unmarshalTextType):
@@ -137,6 +154,9 @@ func compile(typ *runtime.Type, structName, fieldName string, structTypeToDecode
 
 func isStringTagSupportedType(typ *runtime.Type) bool {
 	switch {
+	case isBigNumberType(typ):
+		// decoded by bigNumberDecoder instead of UnmarshalJSON
+		return true
 	case implementsUnmarshalJSONType(runtime.PtrTo(typ)):
 		return false
 	case runtime.PtrTo(typ).Implements(unmarshalTextType):
@@ -299,7 +319,54 @@ func compileArray(typ *runtime.Type, structName, fieldName string, structTypeToD
 	if err != nil {
 		return nil, err
 	}
-	return newArrayDecoder(decoder, elem, typ.Len(), structName, fieldName), nil
+	dec := newArrayDecoder(decoder, elem, typ.Len(), structName, fieldName)
+	if _, ok := decoder.(*uintDecoder); ok && elem.Kind() == reflect.Uint8 {
+		return newByteArrayDecoder(dec), nil
+	}
+	return dec, nil
+}
+
+// compileFormat applies the `format` option of the struct tag to time.Time, []byte or [N]byte decoder in dec.
+func compileFormat(dec Decoder, format string) Decoder {
+	switch d := dec.(type) {
+	case *timeDecoder:
+		return newTimeDecoder(format, d.structName, d.fieldName)
+	case *bytesDecoder:
+		if enc, ok := runtime.BytesEncodingByName(format); ok {
+			copied := *d
+			copied.encoding = enc
+			return &copied
+		}
+	case *byteArrayDecoder:
+		if enc, ok := runtime.BytesEncodingByName(format); ok {
+			copied := *d
+			copied.encoding = enc
+			return &copied
+		}
+	case *ptrDecoder:
+		return newPtrDecoder(compileFormat(d.dec, format), d.typ, d.structName, d.fieldName)
+	case *sliceDecoder, *arrayDecoder, *mapDecoder:
+		return compileElemTimeFormat(dec, format)
+	}
+	return dec
+}
+
+// compileElemTimeFormat applies the time format to the time.Time values in the slices, the arrays and the maps as the encoder does.
+// The bytes encodings are not applied to the elements since [][]byte is an array of strings of the default encoding.
+func compileElemTimeFormat(dec Decoder, format string) Decoder {
+	switch d := dec.(type) {
+	case *timeDecoder:
+		return newTimeDecoder(format, d.structName, d.fieldName)
+	case *ptrDecoder:
+		return newPtrDecoder(compileElemTimeFormat(d.dec, format), d.typ, d.structName, d.fieldName)
+	case *sliceDecoder:
+		return newSliceDecoder(compileElemTimeFormat(d.valueDecoder, format), d.elemType, d.size, d.structName, d.fieldName)
+	case *arrayDecoder:
+		return newArrayDecoder(compileElemTimeFormat(d.valueDecoder, format), d.elemType, d.alen, d.structName, d.fieldName)
+	case *mapDecoder:
+		return newMapDecoder(d.mapType, d.keyType, d.keyDecoder, d.valueType, compileElemTimeFormat(d.valueDecoder, format), d.structName, d.fieldName)
+	}
+	return dec
 }
 
 func compileMap(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
@@ -324,6 +391,11 @@ func compileFunc(typ *runtime.Type, strutName, fieldName string) (Decoder, error
 
 func removeConflictFields(fieldMap map[string]*structFieldSet, conflictedMap map[string]struct{}, dec *structDecoder, field reflect.StructField) {
 	for k, v := range dec.fieldMap {
+		if v.key != k {
+			// the lower case alias is added with its key below.
+			// otherwise it conflicts with the key if the key comes first in the map order.
+			continue
+		}
 		if _, exists := conflictedMap[k]; exists {
 			// already conflicted key
 			continue
@@ -421,6 +493,10 @@ func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeTo
 				}
 				if dec, ok := contentDec.(*structDecoder); ok {
 					for k, v := range dec.fieldMap {
+						if v.key != k {
+							// the lower case alias is added with its key below
+							continue
+						}
 						if _, exists := conflictedMap[k]; exists {
 							// already conflicted key
 							continue
@@ -480,6 +556,9 @@ func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeTo
 			if tag.IsString && isStringTagSupportedType(runtime.Type2RType(field.Type)) {
 				dec = newWrappedStringDecoder(runtime.Type2RType(field.Type), dec, structName, field.Name)
 			}
+			if tag.Format != "" {
+				dec = compileFormat(dec, tag.Format)
+			}
 			var key string
 			if tag.Key != "" {
 				key = tag.Key
@@ -11,7 +11,7 @@ import (
 func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
 	typeptr := uintptr(unsafe.Pointer(typ))
 	if typeptr > typeAddr.MaxTypeAddr {
-		return compileToGetDecoderSlowPath(typeptr, typ)
+		return compileToGetDecoderSlowPath(typ)
 	}
 
 	index := (typeptr - typeAddr.BaseTypeAddr) >> typeAddr.AddrShift
@@ -24,5 +24,6 @@ func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
 		return nil, err
 	}
 	cachedDecoder[index] = dec
+	addCachedType(typ)
 	return dec, nil
 }
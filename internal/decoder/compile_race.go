@@ -14,7 +14,7 @@ var decMu sync.RWMutex
 func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
 	typeptr := uintptr(unsafe.Pointer(typ))
 	if typeptr > typeAddr.MaxTypeAddr {
-		return compileToGetDecoderSlowPath(typeptr, typ)
+		return compileToGetDecoderSlowPath(typ)
 	}
 
 	index := (typeptr - typeAddr.BaseTypeAddr) >> typeAddr.AddrShift
@@ -32,5 +32,6 @@ func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
 	decMu.Lock()
 	cachedDecoder[index] = dec
 	decMu.Unlock()
+	addCachedType(typ)
 	return dec, nil
 }
@@ -45,8 +45,18 @@ func char(ptr unsafe.Pointer, offset int64) byte {
 	return *(*byte)(unsafe.Pointer(uintptr(ptr) + uintptr(offset)))
 }
 
+// bufChar returns buf[cursor], or nul if cursor is at or beyond the end of buf.
+// The input decoded in place has no nul sentinel at the end ( see NoCopyOption ),
+// so the decoders check the end of buf explicitly instead of relying on the sentinel.
+func bufChar(buf []byte, cursor int64) byte {
+	if cursor < int64(len(buf)) {
+		return buf[cursor]
+	}
+	return nul
+}
+
 func skipWhiteSpace(buf []byte, cursor int64) int64 {
-	for isWhiteSpace[buf[cursor]] {
+	for isWhiteSpace[bufChar(buf, cursor)] {
 		cursor++
 	}
 	return cursor
@@ -55,12 +65,12 @@ func skipWhiteSpace(buf []byte, cursor int64) int64 {
 func skipObject(buf []byte, cursor, depth int64) (int64, error) {
 	braceCount := 1
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case '{':
 			braceCount++
 			depth++
 			if depth > maxDecodeNestingDepth {
-				return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+				return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 			}
 		case '}':
 			depth--
@@ -71,17 +81,17 @@ func skipObject(buf []byte, cursor, depth int64) (int64, error) {
 		case '[':
 			depth++
 			if depth > maxDecodeNestingDepth {
-				return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+				return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 			}
 		case ']':
 			depth--
 		case '"':
 			for {
 				cursor++
-				switch buf[cursor] {
+				switch bufChar(buf, cursor) {
 				case '\\':
 					cursor++
-					if buf[cursor] == nul {
+					if bufChar(buf, cursor) == nul {
 						return 0, errors.ErrUnexpectedEndOfJSON("string of object", cursor)
 					}
 				case '"':
@@ -101,12 +111,12 @@ func skipObject(buf []byte, cursor, depth int64) (int64, error) {
 func skipArray(buf []byte, cursor, depth int64) (int64, error) {
 	bracketCount := 1
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case '[':
 			bracketCount++
 			depth++
 			if depth > maxDecodeNestingDepth {
-				return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+				return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 			}
 		case ']':
 			bracketCount--
@@ -117,17 +127,17 @@ func skipArray(buf []byte, cursor, depth int64) (int64, error) {
 		case '{':
 			depth++
 			if depth > maxDecodeNestingDepth {
-				return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+				return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 			}
 		case '}':
 			depth--
 		case '"':
 			for {
 				cursor++
-				switch buf[cursor] {
+				switch bufChar(buf, cursor) {
 				case '\\':
 					cursor++
-					if buf[cursor] == nul {
+					if bufChar(buf, cursor) == nul {
 						return 0, errors.ErrUnexpectedEndOfJSON("string of object", cursor)
 					}
 				case '"':
@@ -146,7 +156,7 @@ func skipArray(buf []byte, cursor, depth int64) (int64, error) {
 
 func skipValue(buf []byte, cursor, depth int64) (int64, error) {
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case ' ', '\t', '\n', '\r':
 			cursor++
 			continue
@@ -157,10 +167,10 @@ func skipValue(buf []byte, cursor, depth int64) (int64, error) {
 		case '"':
 			for {
 				cursor++
-				switch buf[cursor] {
+				switch bufChar(buf, cursor) {
 				case '\\':
 					cursor++
-					if buf[cursor] == nul {
+					if bufChar(buf, cursor) == nul {
 						return 0, errors.ErrUnexpectedEndOfJSON("string of object", cursor)
 					}
 				case '"':
@@ -172,7 +182,7 @@ func skipValue(buf []byte, cursor, depth int64) (int64, error) {
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			for {
 				cursor++
-				if floatTable[buf[cursor]] {
+				if floatTable[bufChar(buf, cursor)] {
 					continue
 				}
 				break
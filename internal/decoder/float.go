@@ -94,14 +94,14 @@ ERROR:
 
 func (d *floatDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error) {
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 			continue
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			start := cursor
 			cursor++
-			for floatTable[buf[cursor]] {
+			for floatTable[bufChar(buf, cursor)] {
 				cursor++
 			}
 			num := buf[start:cursor]
@@ -145,7 +145,7 @@ func (d *floatDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 		return c, nil
 	}
 	cursor = c
-	if !validEndNumberChar[buf[cursor]] {
+	if !validEndNumberChar[bufChar(buf, cursor)] {
 		return 0, errors.ErrUnexpectedEndOfJSON("float", cursor)
 	}
 	s := *(*string)(unsafe.Pointer(&bytes))
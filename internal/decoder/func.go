@@ -137,5 +137,5 @@ func (d *funcDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.
 			}
 		}
 	}
-	return cursor, errors.ErrInvalidBeginningOfValue(buf[cursor], cursor)
+	return cursor, errors.ErrInvalidBeginningOfValue(bufChar(buf, cursor), cursor)
 }
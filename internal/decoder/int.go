@@ -149,9 +149,8 @@ ERROR:
 }
 
 func (d *intDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error) {
-	b := (*sliceHeader)(unsafe.Pointer(&buf)).data
 	for {
-		switch char(b, cursor) {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 			continue
@@ -161,7 +160,7 @@ func (d *intDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error)
 		case '-', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			start := cursor
 			cursor++
-			for numTable[char(b, cursor)] {
+			for numTable[bufChar(buf, cursor)] {
 				cursor++
 			}
 			num := buf[start:cursor]
@@ -173,7 +172,7 @@ func (d *intDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error)
 			cursor += 4
 			return nil, cursor, nil
 		default:
-			return nil, 0, d.typeError([]byte{char(b, cursor)}, cursor)
+			return nil, 0, d.typeError([]byte{bufChar(buf, cursor)}, cursor)
 		}
 	}
 }
@@ -17,8 +17,10 @@ type interfaceDecoder struct {
 	fieldName     string
 	sliceDecoder  *sliceDecoder
 	mapDecoder    *mapDecoder
+	objectDecoder *orderedObjectDecoder
 	floatDecoder  *floatDecoder
 	numberDecoder *numberDecoder
+	optionDecoder *interfaceNumberDecoder
 	stringDecoder *stringDecoder
 }
 
@@ -33,6 +35,7 @@ func newEmptyInterfaceDecoder(structName, fieldName string) *interfaceDecoder {
 		numberDecoder: newNumberDecoder(structName, fieldName, func(p unsafe.Pointer, v json.Number) {
 			*(*interface{})(p) = v
 		}),
+		optionDecoder: newInterfaceNumberDecoder(structName, fieldName),
 		stringDecoder: newStringDecoder(structName, fieldName),
 	}
 	ifaceDecoder.sliceDecoder = newSliceDecoder(
@@ -50,6 +53,7 @@ func newEmptyInterfaceDecoder(structName, fieldName string) *interfaceDecoder {
 		structName,
 		fieldName,
 	)
+	ifaceDecoder.objectDecoder = newOrderedObjectDecoder(ifaceDecoder, structName, fieldName)
 	return ifaceDecoder
 }
 
@@ -75,12 +79,14 @@ func newInterfaceDecoder(typ *runtime.Type, structName, fieldName string) *inter
 			structName,
 			fieldName,
 		),
+		objectDecoder: newOrderedObjectDecoder(emptyIfaceDecoder, structName, fieldName),
 		floatDecoder: newFloatDecoder(structName, fieldName, func(p unsafe.Pointer, v float64) {
 			*(*interface{})(p) = v
 		}),
 		numberDecoder: newNumberDecoder(structName, fieldName, func(p unsafe.Pointer, v json.Number) {
 			*(*interface{})(p) = v
 		}),
+		optionDecoder: newInterfaceNumberDecoder(structName, fieldName),
 		stringDecoder: stringDecoder,
 	}
 }
@@ -89,6 +95,9 @@ func (d *interfaceDecoder) numDecoder(s *Stream) Decoder {
 	if s.UseNumber {
 		return d.numberDecoder
 	}
+	if s.Option.Flags&interfaceNumberOptions != 0 {
+		return d.optionDecoder
+	}
 	return d.floatDecoder
 }
 
@@ -212,6 +221,14 @@ func (d *interfaceDecoder) decodeStreamEmptyInterface(s *Stream, depth int64, p
 	for {
 		switch c {
 		case '{':
+			if s.Option.Flags&UseOrderedObjectOption != 0 {
+				var v runtime.OrderedObject
+				if err := d.objectDecoder.DecodeStream(s, depth, unsafe.Pointer(&v)); err != nil {
+					return err
+				}
+				*(*interface{})(p) = v
+				return nil
+			}
 			var v map[string]interface{}
 			ptr := unsafe.Pointer(&v)
 			if err := d.mapDecoder.DecodeStream(s, depth, ptr); err != nil {
@@ -362,7 +379,7 @@ func (d *interfaceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p un
 			return decodeTextUnmarshaler(buf, cursor, depth, u, p)
 		}
 		cursor = skipWhiteSpace(buf, cursor)
-		if buf[cursor] == 'n' {
+		if bufChar(buf, cursor) == 'n' {
 			if err := validateNull(buf, cursor); err != nil {
 				return 0, err
 			}
@@ -384,7 +401,7 @@ func (d *interfaceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p un
 		return d.decodeEmptyInterface(ctx, cursor, depth, p)
 	}
 	cursor = skipWhiteSpace(buf, cursor)
-	if buf[cursor] == 'n' {
+	if bufChar(buf, cursor) == 'n' {
 		if err := validateNull(buf, cursor); err != nil {
 			return 0, err
 		}
@@ -402,8 +419,17 @@ func (d *interfaceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p un
 func (d *interfaceDecoder) decodeEmptyInterface(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
 	buf := ctx.Buf
 	cursor = skipWhiteSpace(buf, cursor)
-	switch buf[cursor] {
+	switch bufChar(buf, cursor) {
 	case '{':
+		if ctx.Option.Flags&UseOrderedObjectOption != 0 {
+			var v runtime.OrderedObject
+			cursor, err := d.objectDecoder.Decode(ctx, cursor, depth, unsafe.Pointer(&v))
+			if err != nil {
+				return 0, err
+			}
+			**(**interface{})(unsafe.Pointer(&p)) = v
+			return cursor, nil
+		}
 		var v map[string]interface{}
 		ptr := unsafe.Pointer(&v)
 		cursor, err := d.mapDecoder.Decode(ctx, cursor, depth, ptr)
@@ -422,6 +448,9 @@ func (d *interfaceDecoder) decodeEmptyInterface(ctx *RuntimeContext, cursor, dep
 		**(**interface{})(unsafe.Pointer(&p)) = v
 		return cursor, nil
 	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		if ctx.Option.Flags&interfaceNumberOptions != 0 {
+			return d.optionDecoder.Decode(ctx, cursor, depth, p)
+		}
 		return d.floatDecoder.Decode(ctx, cursor, depth, p)
 	case '"':
 		var v string
@@ -454,5 +483,5 @@ func (d *interfaceDecoder) decodeEmptyInterface(ctx *RuntimeContext, cursor, dep
 		**(**interface{})(unsafe.Pointer(&p)) = nil
 		return cursor, nil
 	}
-	return cursor, errors.ErrInvalidBeginningOfValue(buf[cursor], cursor)
+	return cursor, errors.ErrInvalidBeginningOfValue(bufChar(buf, cursor), cursor)
 }
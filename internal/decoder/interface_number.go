@@ -0,0 +1,111 @@
+package decoder
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// interfaceNumberOptions are the options that change the type of number stored into interface{}.
+const interfaceNumberOptions = UseBigNumberOption | UseInt64Option
+
+// interfaceNumberDecoder decodes a number into interface{} when one of interfaceNumberOptions is set.
+//
+// UseInt64Option stores integer literals as int64, falling back to uint64 for large positive values.
+// -0 is stored as float64 so that its sign is not lost.
+// UseBigNumberOption stores integer literals that overflow int64 as *big.Int.
+// Any other number is stored as float64 as in the default behavior.
+type interfaceNumberDecoder struct {
+	floatDecoder *floatDecoder
+	structName   string
+	fieldName    string
+}
+
+func newInterfaceNumberDecoder(structName, fieldName string) *interfaceNumberDecoder {
+	return &interfaceNumberDecoder{
+		floatDecoder: newFloatDecoder(structName, fieldName, nil),
+		structName:   structName,
+		fieldName:    fieldName,
+	}
+}
+
+func (d *interfaceNumberDecoder) decode(v string, flags OptionFlags) (interface{}, bool) {
+	if isIntegerLiteral(v) && v != "-0" {
+		i64, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			if flags&UseInt64Option != 0 {
+				return i64, true
+			}
+		} else {
+			if flags&UseBigNumberOption != 0 {
+				return new(big.Int).SetString(v, 10)
+			}
+			if flags&UseInt64Option != 0 {
+				if u64, err := strconv.ParseUint(v, 10, 64); err == nil {
+					return u64, true
+				}
+			}
+		}
+	}
+	f64, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, false
+	}
+	return f64, true
+}
+
+func isIntegerLiteral(v string) bool {
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '.', 'e', 'E':
+			return false
+		}
+	}
+	return true
+}
+
+func (d *interfaceNumberDecoder) syntaxError(v string, offset int64) *errors.SyntaxError {
+	return errors.ErrSyntax(fmt.Sprintf("invalid number literal %q", v), offset)
+}
+
+func (d *interfaceNumberDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	bytes, err := d.floatDecoder.decodeStreamByte(s)
+	if err != nil {
+		return err
+	}
+	if bytes == nil {
+		return nil
+	}
+	str := *(*string)(unsafe.Pointer(&bytes))
+	v, ok := d.decode(str, s.Option.Flags)
+	if !ok {
+		return d.syntaxError(str, s.totalOffset())
+	}
+	*(*interface{})(p) = v
+	return nil
+}
+
+func (d *interfaceNumberDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	bytes, c, err := d.floatDecoder.decodeByte(buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if bytes == nil {
+		return c, nil
+	}
+	cursor = c
+	if !validEndNumberChar[bufChar(buf, cursor)] {
+		return 0, errors.ErrUnexpectedEndOfJSON("number", cursor)
+	}
+	str := *(*string)(unsafe.Pointer(&bytes))
+	v, ok := d.decode(str, ctx.Option.Flags)
+	if !ok {
+		return 0, d.syntaxError(str, cursor)
+	}
+	**(**interface{})(unsafe.Pointer(&p)) = v
+	return cursor, nil
+}
@@ -124,7 +124,7 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 	buf := ctx.Buf
 	depth++
 	if depth > maxDecodeNestingDepth {
-		return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+		return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 	}
 
 	cursor = skipWhiteSpace(buf, cursor)
@@ -132,7 +132,7 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 	if buflen < 2 {
 		return 0, errors.ErrExpected("{} for map", cursor)
 	}
-	switch buf[cursor] {
+	switch bufChar(buf, cursor) {
 	case 'n':
 		if err := validateNull(buf, cursor); err != nil {
 			return 0, err
@@ -150,7 +150,7 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 	if mapValue == nil {
 		mapValue = makemap(d.mapType, 0)
 	}
-	if buf[cursor] == '}' {
+	if bufChar(buf, cursor) == '}' {
 		**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
 		cursor++
 		return cursor, nil
@@ -162,7 +162,7 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 			return 0, err
 		}
 		cursor = skipWhiteSpace(buf, keyCursor)
-		if buf[cursor] != ':' {
+		if bufChar(buf, cursor) != ':' {
 			return 0, errors.ErrExpected("colon after object key", cursor)
 		}
 		cursor++
@@ -173,12 +173,12 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 		}
 		d.mapassign(d.mapType, mapValue, k, v)
 		cursor = skipWhiteSpace(buf, valueCursor)
-		if buf[cursor] == '}' {
+		if bufChar(buf, cursor) == '}' {
 			**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
 			cursor++
 			return cursor, nil
 		}
-		if buf[cursor] != ',' {
+		if bufChar(buf, cursor) != ',' {
 			return 0, errors.ErrExpected("comma after object value", cursor)
 		}
 		cursor++
@@ -47,6 +47,10 @@ func (d *numberDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 	}
 	cursor = c
 	s := *(*string)(unsafe.Pointer(&bytes))
+	if ctx.Option.Flags&(NoCopyOption|ZeroCopyOption) == NoCopyOption {
+		// the buffer is the input given by the caller, so the number must not point into it
+		s = string(bytes)
+	}
 	d.op(p, json.Number(s))
 	return cursor, nil
 }
@@ -85,14 +89,14 @@ ERROR:
 
 func (d *numberDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error) {
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 			continue
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			start := cursor
 			cursor++
-			for floatTable[buf[cursor]] {
+			for floatTable[bufChar(buf, cursor)] {
 				cursor++
 			}
 			num := buf[start:cursor]
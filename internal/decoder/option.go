@@ -1,15 +1,31 @@
 package decoder
 
-import "context"
+import (
+	"context"
 
-type OptionFlags uint8
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+type OptionFlags uint16
 
 const (
 	FirstWinOption OptionFlags = 1 << iota
 	ContextOption
+	UseBigNumberOption
+	UseInt64Option
+	UseOrderedObjectOption
+	TimeFormatOption
+	BytesEncodingOption
+	AnyBytesEncodingOption
+	NoCopyOption
+	ZeroCopyOption
+	ParallelOption
 )
 
 type Option struct {
-	Flags   OptionFlags
-	Context context.Context
+	Flags         OptionFlags
+	Context       context.Context
+	TimeFormat    string
+	BytesEncoding runtime.BytesEncoding
+	Parallelism   int
 }
@@ -0,0 +1,140 @@
+package decoder
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+var orderedObjectType = runtime.Type2RType(reflect.TypeOf(runtime.OrderedObject{}))
+
+type orderedObjectDecoder struct {
+	keyDecoder   *stringDecoder
+	valueDecoder Decoder
+	structName   string
+	fieldName    string
+}
+
+func newOrderedObjectDecoder(valueDec Decoder, structName, fieldName string) *orderedObjectDecoder {
+	return &orderedObjectDecoder{
+		keyDecoder:   newStringDecoder(structName, fieldName),
+		valueDecoder: valueDec,
+		structName:   structName,
+		fieldName:    fieldName,
+	}
+}
+
+func compileOrderedObject(structName, fieldName string) (Decoder, error) {
+	return newOrderedObjectDecoder(newEmptyInterfaceDecoder(structName, fieldName), structName, fieldName), nil
+}
+
+func (d *orderedObjectDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return errors.ErrExceededMaxDepth(s.char(), s.cursor)
+	}
+
+	switch s.skipWhiteSpace() {
+	case 'n':
+		if err := nullBytes(s); err != nil {
+			return err
+		}
+		**(**runtime.OrderedObject)(unsafe.Pointer(&p)) = nil
+		return nil
+	case '{':
+	default:
+		return errors.ErrExpected("{ character for object value", s.totalOffset())
+	}
+	obj := runtime.OrderedObject{}
+	if s.buf[s.cursor+1] == '}' {
+		**(**runtime.OrderedObject)(unsafe.Pointer(&p)) = obj
+		s.cursor += 2
+		return nil
+	}
+	for {
+		s.cursor++
+		var key string
+		if err := d.keyDecoder.DecodeStream(s, depth, unsafe.Pointer(&key)); err != nil {
+			return err
+		}
+		s.skipWhiteSpace()
+		if !s.equalChar(':') {
+			return errors.ErrExpected("colon after object key", s.totalOffset())
+		}
+		s.cursor++
+		var value interface{}
+		if err := d.valueDecoder.DecodeStream(s, depth, unsafe.Pointer(&value)); err != nil {
+			return err
+		}
+		obj = append(obj, runtime.OrderedObjectEntry{Key: key, Value: value})
+		s.skipWhiteSpace()
+		if s.equalChar('}') {
+			**(**runtime.OrderedObject)(unsafe.Pointer(&p)) = obj
+			s.cursor++
+			return nil
+		}
+		if !s.equalChar(',') {
+			return errors.ErrExpected("comma after object value", s.totalOffset())
+		}
+	}
+}
+
+func (d *orderedObjectDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
+	}
+
+	cursor = skipWhiteSpace(buf, cursor)
+	switch bufChar(buf, cursor) {
+	case 'n':
+		if err := validateNull(buf, cursor); err != nil {
+			return 0, err
+		}
+		cursor += 4
+		**(**runtime.OrderedObject)(unsafe.Pointer(&p)) = nil
+		return cursor, nil
+	case '{':
+	default:
+		return 0, errors.ErrExpected("{ character for object value", cursor)
+	}
+	cursor++
+	cursor = skipWhiteSpace(buf, cursor)
+	obj := runtime.OrderedObject{}
+	if bufChar(buf, cursor) == '}' {
+		**(**runtime.OrderedObject)(unsafe.Pointer(&p)) = obj
+		cursor++
+		return cursor, nil
+	}
+	for {
+		var key string
+		keyCursor, err := d.keyDecoder.Decode(ctx, cursor, depth, unsafe.Pointer(&key))
+		if err != nil {
+			return 0, err
+		}
+		cursor = skipWhiteSpace(buf, keyCursor)
+		if bufChar(buf, cursor) != ':' {
+			return 0, errors.ErrExpected("colon after object key", cursor)
+		}
+		cursor++
+		var value interface{}
+		valueCursor, err := d.valueDecoder.Decode(ctx, cursor, depth, unsafe.Pointer(&value))
+		if err != nil {
+			return 0, err
+		}
+		obj = append(obj, runtime.OrderedObjectEntry{Key: key, Value: value})
+		cursor = skipWhiteSpace(buf, valueCursor)
+		if bufChar(buf, cursor) == '}' {
+			**(**runtime.OrderedObject)(unsafe.Pointer(&p)) = obj
+			cursor++
+			return cursor, nil
+		}
+		if bufChar(buf, cursor) != ',' {
+			return 0, errors.ErrExpected("comma after object value", cursor)
+		}
+		cursor++
+	}
+}
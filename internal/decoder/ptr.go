@@ -61,7 +61,7 @@ func (d *ptrDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) erro
 func (d *ptrDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
 	buf := ctx.Buf
 	cursor = skipWhiteSpace(buf, cursor)
-	if buf[cursor] == 'n' {
+	if bufChar(buf, cursor) == 'n' {
 		if err := validateNull(buf, cursor); err != nil {
 			return 0, err
 		}
@@ -0,0 +1,30 @@
+package decoder
+
+// The functions below are the bounded scanners exported for jsongen.Lexer, which reads the buffer passed to
+// the UnmarshalJSON methods generated by cmd/jsongen. Like the other bounded scanners, they do not require nul
+// at the end of buf, so that the buffer is read without copying it.
+
+// ScanValue returns the cursor after the value at cursor in buf, or false if the value is invalid.
+func ScanValue(buf []byte, cursor int) (int, bool) {
+	return scanBoundedValue(buf, cursor, 0)
+}
+
+// ScanString returns the cursor after the string literal whose opening quote is at cursor in buf,
+// or false if the literal is not terminated or has invalid escape sequences or control characters.
+func ScanString(buf []byte, cursor int) (int, bool) {
+	return scanBoundedString(buf, cursor)
+}
+
+// ScanNumber returns the cursor after the number literal at cursor in buf, or false if it is not a number.
+func ScanNumber(buf []byte, cursor int) (int, bool) {
+	if cursor == len(buf) {
+		return 0, false
+	}
+	return scanBoundedNumber(buf, cursor)
+}
+
+// UnescapeString returns a copy of the string literal without the quotes whose escape sequences are decoded.
+// offset is the position of the literal in the input, and is used for errors.
+func UnescapeString(literal []byte, offset int64) ([]byte, error) {
+	return unescapeString(literal, offset)
+}
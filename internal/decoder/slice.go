@@ -210,11 +210,11 @@ func (d *sliceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 	buf := ctx.Buf
 	depth++
 	if depth > maxDecodeNestingDepth {
-		return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+		return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 	}
 
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 			continue
@@ -226,9 +226,17 @@ func (d *sliceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 			typedmemmove(sliceType, p, nilSlice)
 			return cursor, nil
 		case '[':
+			if depth == 1 && ctx.Option.Flags&ParallelOption != 0 {
+				if starts, end, ok := splitSliceElems(buf, cursor, depth); ok {
+					if err := d.decodeParallel(ctx, starts, depth, p); err != nil {
+						return 0, err
+					}
+					return end, nil
+				}
+			}
 			cursor++
 			cursor = skipWhiteSpace(buf, cursor)
-			if buf[cursor] == ']' {
+			if bufChar(buf, cursor) == ']' {
 				dst := (*sliceHeader)(p)
 				if dst.data == nil {
 					dst.data = newArray(d.elemType, 0)
@@ -267,7 +275,7 @@ func (d *sliceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 				}
 				cursor = c
 				cursor = skipWhiteSpace(buf, cursor)
-				switch buf[cursor] {
+				switch bufChar(buf, cursor) {
 				case ']':
 					slice.cap = capacity
 					slice.len = idx + 1
@@ -288,7 +296,7 @@ func (d *sliceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 					slice.cap = capacity
 					slice.data = data
 					d.releaseSlice(slice)
-					return 0, errors.ErrInvalidCharacter(buf[cursor], "slice", cursor)
+					return 0, errors.ErrInvalidCharacter(bufChar(buf, cursor), "slice", cursor)
 				}
 				cursor++
 			}
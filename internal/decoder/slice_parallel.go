@@ -0,0 +1,76 @@
+package decoder
+
+import (
+	goruntime "runtime"
+	"sync"
+	"unsafe"
+)
+
+// splitSliceElems returns the offsets of the elements of the array starting at cursor, and the cursor after the array.
+// It returns false if the array is empty or cannot be split, and then the array is decoded sequentially to report the error.
+func splitSliceElems(buf []byte, cursor, depth int64) ([]int64, int64, bool) {
+	cursor = skipWhiteSpace(buf, cursor+1)
+	if bufChar(buf, cursor) == ']' {
+		return nil, 0, false
+	}
+	var starts []int64
+	for {
+		starts = append(starts, cursor)
+		end, err := skipValue(buf, cursor, depth)
+		if err != nil {
+			return nil, 0, false
+		}
+		cursor = skipWhiteSpace(buf, end)
+		switch bufChar(buf, cursor) {
+		case ']':
+			return starts, cursor + 1, true
+		case ',':
+			cursor = skipWhiteSpace(buf, cursor+1)
+		default:
+			return nil, 0, false
+		}
+	}
+}
+
+// decodeParallel decodes the elements starting at starts concurrently into a new slice, and stores it in p on success.
+// The elements are divided into contiguous chunks per worker, so the error of the smallest index is returned.
+func (d *sliceDecoder) decodeParallel(ctx *RuntimeContext, starts []int64, depth int64, p unsafe.Pointer) error {
+	n := len(starts)
+	workers := ctx.Option.Parallelism
+	if workers <= 0 {
+		workers = goruntime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	data := newArray(d.elemType, n)
+	if src := (*sliceHeader)(p); src.len > 0 {
+		// keep the original references as sequential decoding does
+		copySlice(d.elemType, sliceHeader{data: data, len: n, cap: n}, *src)
+	}
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w, from, to int) {
+			defer wg.Done()
+			// the decoders may replace Buf temporarily, so each worker has its own context
+			wctx := &RuntimeContext{Buf: ctx.Buf, Option: ctx.Option}
+			for i := from; i < to; i++ {
+				ep := unsafe.Pointer(uintptr(data) + uintptr(i)*d.size)
+				if _, err := d.valueDecoder.Decode(wctx, starts[i], depth, ep); err != nil {
+					errs[w] = err
+					return
+				}
+			}
+		}(w, n*w/workers, n*(w+1)/workers)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	*(*sliceHeader)(p) = sliceHeader{data: data, len: n, cap: n}
+	return nil
+}
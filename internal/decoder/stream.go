@@ -23,6 +23,7 @@ type Stream struct {
 	cursor                int64
 	filledBuffer          bool
 	allRead               bool
+	readErr               error
 	UseNumber             bool
 	DisallowUnknownFields bool
 	Option                *Option
@@ -221,11 +222,40 @@ func (s *Stream) read() bool {
 	if err == io.EOF {
 		s.allRead = true
 	} else if err != nil {
+		s.readErr = err
 		return false
 	}
 	return true
 }
 
+// ReadRecord reads the bytes until the first occurrence of delim, which is replaced by nul in the buffer.
+// The returned record excludes delim and is followed by nul within its capacity, so that it is decoded in place by Unmarshal.
+// At the end of input, it returns the remaining bytes with io.EOF, or with the error of the reader.
+//
+// The stream never overwrites the bytes read before, so the values decoded from a record can point into it
+// as the values decoded by DecodeStream do.
+func (s *Stream) ReadRecord(delim byte) ([]byte, error) {
+	s.reset()
+	cursor := int64(0)
+	for {
+		for ; cursor < s.length; cursor++ {
+			if s.buf[cursor] == delim {
+				s.buf[cursor] = nul
+				s.cursor = cursor + 1
+				return s.buf[:cursor], nil
+			}
+		}
+		if !s.read() {
+			break
+		}
+	}
+	s.cursor = s.length
+	if s.readErr != nil {
+		return s.buf[:s.length], s.readErr
+	}
+	return s.buf[:s.length], io.EOF
+}
+
 func (s *Stream) skipWhiteSpace() byte {
 	p := s.bufptr()
 LOOP:
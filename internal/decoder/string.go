@@ -8,6 +8,7 @@ import (
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/simd"
 )
 
 type stringDecoder struct {
@@ -54,6 +55,11 @@ func (d *stringDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 		return c, nil
 	}
 	cursor = c
+	if ctx.Option.Flags&(NoCopyOption|ZeroCopyOption) == NoCopyOption {
+		// the buffer is the input given by the caller, so the string must not point into it
+		**(**string)(unsafe.Pointer(&p)) = string(bytes)
+		return cursor, nil
+	}
 	**(**string)(unsafe.Pointer(&p)) = *(*string)(unsafe.Pointer(&bytes))
 	return cursor, nil
 }
@@ -295,7 +301,7 @@ func (d *stringDecoder) decodeStreamByte(s *Stream) ([]byte, error) {
 
 func (d *stringDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error) {
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 		case '[':
@@ -307,53 +313,32 @@ func (d *stringDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, err
 		case '"':
 			cursor++
 			start := cursor
-			b := (*sliceHeader)(unsafe.Pointer(&buf)).data
+			escaped := false
 			for {
-				switch char(b, cursor) {
+				cursor = stringEnd(buf, cursor)
+				if cursor < 0 {
+					// the input decoded in place ends without the nul sentinel ( see NoCopyOption )
+					return nil, 0, errors.ErrUnexpectedEndOfJSON("string", int64(len(buf)))
+				}
+				switch bufChar(buf, cursor) {
 				case '\\':
+					escaped = true
 					cursor++
-					switch char(b, cursor) {
-					case '"':
-						buf[cursor] = '"'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case '\\':
-						buf[cursor] = '\\'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case '/':
-						buf[cursor] = '/'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'b':
-						buf[cursor] = '\b'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'f':
-						buf[cursor] = '\f'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'n':
-						buf[cursor] = '\n'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'r':
-						buf[cursor] = '\r'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 't':
-						buf[cursor] = '\t'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'u':
-						buflen := int64(len(buf))
-						if cursor+5 >= buflen {
-							return nil, 0, errors.ErrUnexpectedEndOfJSON("escaped string", cursor)
-						}
-						code := unicodeToRune(buf[cursor+1 : cursor+5])
-						unicode := []byte(string(code))
-						buf = append(append(buf[:cursor-1], unicode...), buf[cursor+5:]...)
-					default:
+					if cursor == int64(len(buf)) || bufChar(buf, cursor) == nul {
 						return nil, 0, errors.ErrUnexpectedEndOfJSON("escaped string", cursor)
 					}
-					continue
 				case '"':
 					literal := buf[start:cursor]
+					if escaped {
+						unescaped, err := unescapeString(literal, start)
+						if err != nil {
+							return nil, 0, err
+						}
+						literal = unescaped
+					}
 					cursor++
 					return literal, cursor, nil
-				case nul:
+				default:
 					return nil, 0, errors.ErrUnexpectedEndOfJSON("string", cursor)
 				}
 				cursor++
@@ -365,7 +350,70 @@ func (d *stringDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, err
 			cursor += 4
 			return nil, cursor, nil
 		default:
-			return nil, 0, errors.ErrInvalidBeginningOfValue(buf[cursor], cursor)
+			return nil, 0, errors.ErrInvalidBeginningOfValue(bufChar(buf, cursor), cursor)
+		}
+	}
+}
+
+// stringEnd returns the cursor of the first '"', '\\' or nul from cursor, or -1 if there is no such byte.
+// The first bytes are scanned one by one, since it is faster for short strings than calling the block scanner.
+func stringEnd(buf []byte, cursor int64) int64 {
+	for end := cursor + 16; cursor < end && cursor < int64(len(buf)); cursor++ {
+		switch bufChar(buf, cursor) {
+		case '"', '\\', nul:
+			return cursor
+		}
+	}
+	i := simd.IndexStringEnd(buf[cursor:])
+	if i < 0 {
+		return -1
+	}
+	return cursor + int64(i)
+}
+
+// unescapeString returns a copy of the string literal whose escape sequences are decoded.
+// The literal is not modified because it may be the input given by the caller ( see NoCopyOption ).
+// offset is the position of the literal in the input, and is used for errors.
+func unescapeString(literal []byte, offset int64) ([]byte, error) {
+	dst := make([]byte, 0, len(literal))
+	for i := 0; i < len(literal); i++ {
+		c := literal[i]
+		if c != '\\' {
+			dst = append(dst, c)
+			continue
+		}
+		i++
+		switch literal[i] {
+		case '"', '\\', '/':
+			dst = append(dst, literal[i])
+		case 'b':
+			dst = append(dst, '\b')
+		case 'f':
+			dst = append(dst, '\f')
+		case 'n':
+			dst = append(dst, '\n')
+		case 'r':
+			dst = append(dst, '\r')
+		case 't':
+			dst = append(dst, '\t')
+		case 'u':
+			if i+4 >= len(literal) {
+				return nil, errors.ErrUnexpectedEndOfJSON("escaped string", offset+int64(i))
+			}
+			r := unicodeToRune(literal[i+1 : i+5])
+			i += 4
+			if utf16.IsSurrogate(r) && i+6 < len(literal) && literal[i+1] == '\\' && literal[i+2] == 'u' {
+				if r2 := utf16.DecodeRune(r, unicodeToRune(literal[i+3:i+7])); r2 != unicode.ReplacementChar {
+					r = r2
+					i += 6
+				}
+			}
+			var rb [utf8.UTFMax]byte
+			n := utf8.EncodeRune(rb[:], r)
+			dst = append(dst, rb[:n]...)
+		default:
+			return nil, errors.ErrUnexpectedEndOfJSON("escaped string", offset+int64(i))
 		}
 	}
+	return dst, nil
 }
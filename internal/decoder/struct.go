@@ -165,7 +165,7 @@ func decodeKeyCharByUnicodeRune(buf []byte, cursor int64) ([]byte, int64) {
 	r := unicodeToRune(buf[cursor : cursor+defaultOffset])
 	if utf16.IsSurrogate(r) {
 		cursor += defaultOffset
-		if cursor+surrogateOffset >= int64(len(buf)) || buf[cursor] != '\\' || buf[cursor+1] != 'u' {
+		if cursor+surrogateOffset >= int64(len(buf)) || bufChar(buf, cursor) != '\\' || bufChar(buf, cursor+1) != 'u' {
 			return []byte(string(unicode.ReplacementChar)), cursor + defaultOffset - 1
 		}
 		cursor += 2
@@ -178,7 +178,7 @@ func decodeKeyCharByUnicodeRune(buf []byte, cursor int64) ([]byte, int64) {
 }
 
 func decodeKeyCharByEscapedChar(buf []byte, cursor int64) ([]byte, int64) {
-	c := buf[cursor]
+	c := bufChar(buf, cursor)
 	cursor++
 	switch c {
 	case '"':
@@ -207,14 +207,13 @@ func decodeKeyByBitmapUint8(d *structDecoder, buf []byte, cursor int64) (int64,
 	var (
 		curBit uint8 = math.MaxUint8
 	)
-	b := (*sliceHeader)(unsafe.Pointer(&buf)).data
 	for {
-		switch char(b, cursor) {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 		case '"':
 			cursor++
-			c := char(b, cursor)
+			c := bufChar(buf, cursor)
 			switch c {
 			case '"':
 				cursor++
@@ -226,7 +225,7 @@ func decodeKeyByBitmapUint8(d *structDecoder, buf []byte, cursor int64) (int64,
 			bitmap := d.keyBitmapUint8
 			start := cursor
 			for {
-				c := char(b, cursor)
+				c := bufChar(buf, cursor)
 				switch c {
 				case '"':
 					fieldSetIndex := bits.TrailingZeros8(curBit)
@@ -246,7 +245,7 @@ func decodeKeyByBitmapUint8(d *structDecoder, buf []byte, cursor int64) (int64,
 					for _, c := range chars {
 						curBit &= bitmap[keyIdx][largeToSmallTable[c]]
 						if curBit == 0 {
-							return decodeKeyNotFound(b, cursor)
+							return decodeKeyNotFound(buf, cursor)
 						}
 						keyIdx++
 					}
@@ -254,14 +253,14 @@ func decodeKeyByBitmapUint8(d *structDecoder, buf []byte, cursor int64) (int64,
 				default:
 					curBit &= bitmap[keyIdx][largeToSmallTable[c]]
 					if curBit == 0 {
-						return decodeKeyNotFound(b, cursor)
+						return decodeKeyNotFound(buf, cursor)
 					}
 					keyIdx++
 				}
 				cursor++
 			}
 		default:
-			return cursor, nil, errors.ErrInvalidBeginningOfValue(char(b, cursor), cursor)
+			return cursor, nil, errors.ErrInvalidBeginningOfValue(bufChar(buf, cursor), cursor)
 		}
 	}
 }
@@ -270,14 +269,13 @@ func decodeKeyByBitmapUint16(d *structDecoder, buf []byte, cursor int64) (int64,
 	var (
 		curBit uint16 = math.MaxUint16
 	)
-	b := (*sliceHeader)(unsafe.Pointer(&buf)).data
 	for {
-		switch char(b, cursor) {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 		case '"':
 			cursor++
-			c := char(b, cursor)
+			c := bufChar(buf, cursor)
 			switch c {
 			case '"':
 				cursor++
@@ -289,7 +287,7 @@ func decodeKeyByBitmapUint16(d *structDecoder, buf []byte, cursor int64) (int64,
 			bitmap := d.keyBitmapUint16
 			start := cursor
 			for {
-				c := char(b, cursor)
+				c := bufChar(buf, cursor)
 				switch c {
 				case '"':
 					fieldSetIndex := bits.TrailingZeros16(curBit)
@@ -309,7 +307,7 @@ func decodeKeyByBitmapUint16(d *structDecoder, buf []byte, cursor int64) (int64,
 					for _, c := range chars {
 						curBit &= bitmap[keyIdx][largeToSmallTable[c]]
 						if curBit == 0 {
-							return decodeKeyNotFound(b, cursor)
+							return decodeKeyNotFound(buf, cursor)
 						}
 						keyIdx++
 					}
@@ -317,28 +315,28 @@ func decodeKeyByBitmapUint16(d *structDecoder, buf []byte, cursor int64) (int64,
 				default:
 					curBit &= bitmap[keyIdx][largeToSmallTable[c]]
 					if curBit == 0 {
-						return decodeKeyNotFound(b, cursor)
+						return decodeKeyNotFound(buf, cursor)
 					}
 					keyIdx++
 				}
 				cursor++
 			}
 		default:
-			return cursor, nil, errors.ErrInvalidBeginningOfValue(char(b, cursor), cursor)
+			return cursor, nil, errors.ErrInvalidBeginningOfValue(bufChar(buf, cursor), cursor)
 		}
 	}
 }
 
-func decodeKeyNotFound(b unsafe.Pointer, cursor int64) (int64, *structFieldSet, error) {
+func decodeKeyNotFound(buf []byte, cursor int64) (int64, *structFieldSet, error) {
 	for {
 		cursor++
-		switch char(b, cursor) {
+		switch bufChar(buf, cursor) {
 		case '"':
 			cursor++
 			return cursor, nil, nil
 		case '\\':
 			cursor++
-			if char(b, cursor) == nul {
+			if bufChar(buf, cursor) == nul {
 				return 0, nil, errors.ErrUnexpectedEndOfJSON("string", cursor)
 			}
 		case nul:
@@ -726,12 +724,11 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 	buf := ctx.Buf
 	depth++
 	if depth > maxDecodeNestingDepth {
-		return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+		return 0, errors.ErrExceededMaxDepth(bufChar(buf, cursor), cursor)
 	}
 	buflen := int64(len(buf))
 	cursor = skipWhiteSpace(buf, cursor)
-	b := (*sliceHeader)(unsafe.Pointer(&buf)).data
-	switch char(b, cursor) {
+	switch bufChar(buf, cursor) {
 	case 'n':
 		if err := validateNull(buf, cursor); err != nil {
 			return 0, err
@@ -740,11 +737,11 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 		return cursor, nil
 	case '{':
 	default:
-		return 0, errors.ErrInvalidBeginningOfValue(char(b, cursor), cursor)
+		return 0, errors.ErrInvalidBeginningOfValue(bufChar(buf, cursor), cursor)
 	}
 	cursor++
 	cursor = skipWhiteSpace(buf, cursor)
-	if buf[cursor] == '}' {
+	if bufChar(buf, cursor) == '}' {
 		cursor++
 		return cursor, nil
 	}
@@ -762,7 +759,7 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 			return 0, err
 		}
 		cursor = skipWhiteSpace(buf, c)
-		if char(b, cursor) != ':' {
+		if bufChar(buf, cursor) != ':' {
 			return 0, errors.ErrExpected("colon after object key", cursor)
 		}
 		cursor++
@@ -807,11 +804,11 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 			cursor = c
 		}
 		cursor = skipWhiteSpace(buf, cursor)
-		if char(b, cursor) == '}' {
+		if bufChar(buf, cursor) == '}' {
 			cursor++
 			return cursor, nil
 		}
-		if char(b, cursor) != ',' {
+		if bufChar(buf, cursor) != ',' {
 			return 0, errors.ErrExpected("comma after object element", cursor)
 		}
 		cursor++
@@ -0,0 +1,196 @@
+package decoder
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+var timeType = runtime.Type2RType(reflect.TypeOf(time.Time{}))
+
+// timeDecoder decodes time.Time value without calling UnmarshalJSON.
+// Unix time formats are read from JSON number, and layouts are read from JSON string.
+type timeDecoder struct {
+	stringDecoder *stringDecoder
+	floatDecoder  *floatDecoder
+	format        string
+	structName    string
+	fieldName     string
+}
+
+func newTimeDecoder(format, structName, fieldName string) *timeDecoder {
+	return &timeDecoder{
+		stringDecoder: newStringDecoder(structName, fieldName),
+		floatDecoder:  newFloatDecoder(structName, fieldName, nil),
+		format:        format,
+		structName:    structName,
+		fieldName:     fieldName,
+	}
+}
+
+func compileTime(structName, fieldName string) (Decoder, error) {
+	return newTimeDecoder("", structName, fieldName), nil
+}
+
+func (d *timeDecoder) timeFormat(opt *Option) string {
+	if d.format != "" {
+		return d.format
+	}
+	if (opt.Flags & TimeFormatOption) != 0 {
+		return opt.TimeFormat
+	}
+	return ""
+}
+
+func (d *timeDecoder) typeError(value string, offset int64) *errors.UnmarshalTypeError {
+	return &errors.UnmarshalTypeError{
+		Value:  value,
+		Type:   runtime.RType2Type(timeType),
+		Struct: d.structName,
+		Field:  d.fieldName,
+		Offset: offset,
+	}
+}
+
+func (d *timeDecoder) annotateError(err error) error {
+	if e, ok := err.(*errors.UnmarshalTypeError); ok {
+		e.Type = runtime.RType2Type(timeType)
+	}
+	return err
+}
+
+func (d *timeDecoder) decodeTime(src []byte, format string, offset int64, p unsafe.Pointer) error {
+	if unit, ok := runtime.UnixTimeUnit(format); ok {
+		t, ok := ParseUnixTime(src, unit)
+		if !ok {
+			return d.typeError(fmt.Sprintf("number %s", src), offset)
+		}
+		*(*time.Time)(p) = t
+		return nil
+	}
+	if format == "" {
+		// same as time.Time.UnmarshalJSON, including the error that encoding/json returns
+		return (*time.Time)(p).UnmarshalText(src)
+	}
+	t, err := time.Parse(format, string(src))
+	if err != nil {
+		return d.typeError(fmt.Sprintf("string %q", src), offset)
+	}
+	*(*time.Time)(p) = t
+	return nil
+}
+
+func (d *timeDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	format := d.timeFormat(s.Option)
+	var (
+		src []byte
+		err error
+	)
+	if _, ok := runtime.UnixTimeUnit(format); ok {
+		if typ := nonNumberValueType(s.skipWhiteSpace()); typ != "" {
+			return d.typeError(typ, s.totalOffset())
+		}
+		src, err = d.floatDecoder.decodeStreamByte(s)
+	} else {
+		src, err = d.stringDecoder.decodeStreamByte(s)
+	}
+	if err != nil {
+		return d.annotateError(err)
+	}
+	if src == nil {
+		return nil
+	}
+	return d.decodeTime(src, format, s.totalOffset(), p)
+}
+
+func (d *timeDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	format := d.timeFormat(ctx.Option)
+	var (
+		src []byte
+		c   int64
+		err error
+	)
+	if _, ok := runtime.UnixTimeUnit(format); ok {
+		cursor = skipWhiteSpace(buf, cursor)
+		if typ := nonNumberValueType(bufChar(buf, cursor)); typ != "" {
+			return 0, d.typeError(typ, cursor)
+		}
+		src, c, err = d.floatDecoder.decodeByte(buf, cursor)
+		if err == nil && src != nil && !validEndNumberChar[bufChar(buf, c)] {
+			return 0, errors.ErrUnexpectedEndOfJSON("number", c)
+		}
+	} else {
+		src, c, err = d.stringDecoder.decodeByte(buf, cursor)
+	}
+	if err != nil {
+		return 0, d.annotateError(err)
+	}
+	if src == nil {
+		return c, nil
+	}
+	if err := d.decodeTime(src, format, c, p); err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+func nonNumberValueType(c byte) string {
+	switch c {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	}
+	return ""
+}
+
+// ParseUnixTime parses a number literal in unit since the Unix epoch.
+// The fractional part is kept down to nanoseconds, and the exponent form is not supported.
+func ParseUnixTime(src []byte, unit time.Duration) (time.Time, bool) {
+	s := string(src)
+	neg := false
+	if len(s) > 0 && s[0] == '-' {
+		neg = true
+		s = s[1:]
+	}
+	frac := ""
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			s, frac = s[:i], s[i+1:]
+			if frac == "" {
+				return time.Time{}, false
+			}
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	perSecond := int64(time.Second / unit)
+	sec := n / perSecond
+	nsec := (n % perSecond) * int64(unit)
+	scale := int64(unit)
+	for i := 0; i < len(frac); i++ {
+		c := frac[i]
+		if c < '0' || c > '9' {
+			return time.Time{}, false
+		}
+		scale /= 10
+		nsec += int64(c-'0') * scale
+	}
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return time.Unix(sec, nsec).UTC(), true
+}
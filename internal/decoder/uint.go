@@ -101,7 +101,7 @@ func (d *uintDecoder) decodeStreamByte(s *Stream) ([]byte, error) {
 
 func (d *uintDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error) {
 	for {
-		switch buf[cursor] {
+		switch bufChar(buf, cursor) {
 		case ' ', '\n', '\t', '\r':
 			cursor++
 			continue
@@ -111,7 +111,7 @@ func (d *uintDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error
 		case '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			start := cursor
 			cursor++
-			for numTable[buf[cursor]] {
+			for numTable[bufChar(buf, cursor)] {
 				cursor++
 			}
 			num := buf[start:cursor]
@@ -123,7 +123,7 @@ func (d *uintDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error
 			cursor += 4
 			return nil, cursor, nil
 		default:
-			return nil, 0, d.typeError([]byte{buf[cursor]}, cursor)
+			return nil, 0, d.typeError([]byte{bufChar(buf, cursor)}, cursor)
 		}
 	}
 }
@@ -57,6 +57,10 @@ func (d *wrappedStringDecoder) Decode(ctx *RuntimeContext, cursor, depth int64,
 		}
 		return c, nil
 	}
+	if ctx.Option.Flags&NoCopyOption != 0 {
+		// bytes may point to the input given by the caller, so it must not be modified
+		bytes = append(make([]byte, 0, len(bytes)+1), bytes...)
+	}
 	bytes = append(bytes, nul)
 	oldBuf := ctx.Buf
 	ctx.Buf = bytes
@@ -0,0 +1,318 @@
+package encoder
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/simd"
+)
+
+// Canonicalize appends to dst the canonical form of src defined by RFC 8785 ( JSON Canonicalization Scheme ).
+// Object members are sorted by the UTF-16 code units of their keys, numbers are formatted as ECMAScript does,
+// strings are minimally escaped and whitespace is removed.
+// Duplicate object keys, invalid UTF-8 and lone surrogates are reported as errors as required by I-JSON.
+func Canonicalize(dst, src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, errors.ErrUnexpectedEndOfJSON("", 0)
+	}
+	ctx := TakeRuntimeContext()
+	ctxBuf := ctx.Buf[:0]
+	ctxBuf = append(append(ctxBuf, src...), nul)
+	ctx.Buf = ctxBuf
+
+	dst, cursor, err := canonicalValue(dst, ctxBuf, 0)
+	if err == nil {
+		err = validateEndBuf(ctxBuf, cursor)
+	}
+	ReleaseRuntimeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+func canonicalValue(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor)
+	switch src[cursor] {
+	case '{':
+		return canonicalObject(dst, src, cursor)
+	case '[':
+		return canonicalArray(dst, src, cursor)
+	case '"':
+		s, c, err := canonicalUnquote(src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		return appendCanonicalString(dst, s), c, nil
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		num, c, err := compactNumber(nil, src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		f, _ := strconv.ParseFloat(string(num), 64)
+		return appendCanonicalNumber(dst, f), c, nil
+	}
+	return compactValue(dst, src, cursor, false)
+}
+
+type canonicalMember struct {
+	key   string
+	start int
+	end   int
+}
+
+func canonicalObject(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == '}' {
+		return append(dst, '{', '}'), cursor + 1, nil
+	}
+	var (
+		members []canonicalMember
+		values  []byte
+	)
+	for {
+		cursor = skipWhiteSpace(src, cursor)
+		if src[cursor] != '"' {
+			return nil, 0, errors.ErrExpected("object key", cursor)
+		}
+		key, c, err := canonicalUnquote(src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, c)
+		if src[cursor] != ':' {
+			return nil, 0, errors.ErrExpected("colon after object key", cursor)
+		}
+		start := len(values)
+		values, cursor, err = canonicalValue(values, src, cursor+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		members = append(members, canonicalMember{key: key, start: start, end: len(values)})
+		cursor = skipWhiteSpace(src, cursor)
+		if src[cursor] == '}' {
+			cursor++
+			break
+		}
+		if src[cursor] != ',' {
+			return nil, 0, errors.ErrExpected("comma after object value", cursor)
+		}
+		cursor++
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return utf16Less(members[i].key, members[j].key)
+	})
+	dst = append(dst, '{')
+	for i, member := range members {
+		if i > 0 {
+			if member.key == members[i-1].key {
+				return nil, 0, errors.ErrSyntax("duplicate object key "+strconv.Quote(member.key), cursor)
+			}
+			dst = append(dst, ',')
+		}
+		dst = appendCanonicalString(dst, member.key)
+		dst = append(dst, ':')
+		dst = append(dst, values[member.start:member.end]...)
+	}
+	return append(dst, '}'), cursor, nil
+}
+
+func canonicalArray(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	dst = append(dst, '[')
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == ']' {
+		return append(dst, ']'), cursor + 1, nil
+	}
+	var err error
+	for {
+		dst, cursor, err = canonicalValue(dst, src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, cursor)
+		switch src[cursor] {
+		case ']':
+			return append(dst, ']'), cursor + 1, nil
+		case ',':
+			dst = append(dst, ',')
+		default:
+			return nil, 0, errors.ErrExpected("comma after array value", cursor)
+		}
+		cursor++
+	}
+}
+
+// canonicalUnquote decodes the JSON string starting at cursor.
+func canonicalUnquote(src []byte, cursor int64) (string, int64, error) {
+	raw, end, err := compactString(nil, src, cursor, false)
+	if err != nil {
+		return "", 0, err
+	}
+	raw = raw[1 : len(raw)-1]
+	if i := simd.InvalidUTF8Index(raw); i >= 0 {
+		return "", 0, errors.ErrSyntax("invalid UTF-8 in string", cursor+1+int64(i))
+	}
+	s := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); {
+		c := raw[i]
+		switch {
+		case c == '\\':
+			r, n, ok := unescapeChar(raw[i:])
+			if !ok {
+				return "", 0, errors.ErrSyntax("invalid escape sequence in string", cursor+1+int64(i))
+			}
+			s = append(s, string(r)...)
+			i += n
+		case c < 0x20:
+			return "", 0, errors.ErrInvalidCharacter(c, "string", cursor+1+int64(i))
+		default:
+			s = append(s, c)
+			i++
+		}
+	}
+	return string(s), end, nil
+}
+
+// unescapeChar decodes the escape sequence at the head of b, and returns the rune and the length of the sequence.
+// Surrogate pairs are combined, and lone surrogates are reported as invalid.
+func unescapeChar(b []byte) (rune, int, bool) {
+	if len(b) < 2 {
+		return 0, 0, false
+	}
+	switch b[1] {
+	case '"', '\\', '/':
+		return rune(b[1]), 2, true
+	case 'b':
+		return '\b', 2, true
+	case 'f':
+		return '\f', 2, true
+	case 'n':
+		return '\n', 2, true
+	case 'r':
+		return '\r', 2, true
+	case 't':
+		return '\t', 2, true
+	case 'u':
+		r, ok := unescapeHex(b[2:])
+		if !ok {
+			return 0, 0, false
+		}
+		if !utf16.IsSurrogate(r) {
+			return r, 6, true
+		}
+		if len(b) < 12 || b[6] != '\\' || b[7] != 'u' {
+			return 0, 0, false
+		}
+		r2, ok := unescapeHex(b[8:])
+		if !ok {
+			return 0, 0, false
+		}
+		if r := utf16.DecodeRune(r, r2); r != utf8.RuneError {
+			return r, 12, true
+		}
+	}
+	return 0, 0, false
+}
+
+func unescapeHex(b []byte) (rune, bool) {
+	if len(b) < 4 {
+		return 0, false
+	}
+	var r rune
+	for _, c := range b[:4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return 0, false
+		}
+		r = r<<4 | rune(c)
+	}
+	return r, true
+}
+
+// appendCanonicalString appends s escaped as RFC 8785 section 3.2.2.2.
+func appendCanonicalString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		b = append(b, s[start:i]...)
+		switch c {
+		case '"', '\\':
+			b = append(b, '\\', c)
+		case '\b':
+			b = append(b, '\\', 'b')
+		case '\f':
+			b = append(b, '\\', 'f')
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		case '\t':
+			b = append(b, '\\', 't')
+		default:
+			b = append(b, `\u00`...)
+			b = append(b, hex[c>>4], hex[c&0xF])
+		}
+		start = i + 1
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
+
+// appendCanonicalNumber appends f formatted as ECMAScript Number.prototype.toString does.
+func appendCanonicalNumber(b []byte, f float64) []byte {
+	if f == 0 {
+		// -0 is also written as 0
+		return append(b, '0')
+	}
+	abs := math.Abs(f)
+	if abs >= 1e-6 && abs < 1e21 {
+		return strconv.AppendFloat(b, f, 'f', -1, 64)
+	}
+	b = strconv.AppendFloat(b, f, 'e', -1, 64)
+	// clean up e-09 to e-9
+	n := len(b)
+	if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+		b[n-2] = b[n-1]
+		b = b[:n-1]
+	}
+	return b
+}
+
+// utf16Less compares a and b by their UTF-16 code units.
+func utf16Less(a, b string) bool {
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			ha, la := utf16Units(ra)
+			hb, lb := utf16Units(rb)
+			if ha != hb {
+				return ha < hb
+			}
+			return la < lb
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return len(a) < len(b)
+}
+
+func utf16Units(r rune) (rune, rune) {
+	if r >= 0x10000 {
+		return utf16.EncodeRune(r)
+	}
+	return r, 0
+}
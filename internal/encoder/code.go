@@ -2,6 +2,7 @@ package encoder
 
 import (
 	"fmt"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/runtime"
@@ -54,6 +55,9 @@ const (
 	CodeKindMarshalJSON
 	CodeKindMarshalText
 	CodeKindRecursive
+	CodeKindOrderedObject
+	CodeKindTime
+	CodeKindChan
 )
 
 type IntCode struct {
@@ -211,6 +215,29 @@ func (c *BytesCode) ToOpcode(ctx *compileContext) Opcodes {
 	return Opcodes{code}
 }
 
+type TimeCode struct {
+	typ    *runtime.Type
+	isPtr  bool
+	format string // format option of the struct tag
+}
+
+func (c *TimeCode) Kind() CodeKind {
+	return CodeKindTime
+}
+
+func (c *TimeCode) ToOpcode(ctx *compileContext) Opcodes {
+	var code *Opcode
+	switch {
+	case c.isPtr:
+		code = newOpCode(ctx, c.typ, OpTimePtr)
+	default:
+		code = newOpCode(ctx, c.typ, OpTime)
+	}
+	code.Key = c.format
+	ctx.incIndex()
+	return Opcodes{code}
+}
+
 type SliceCode struct {
 	typ   *runtime.Type
 	value Code
@@ -245,11 +272,81 @@ func (c *SliceCode) ToOpcode(ctx *compileContext) Opcodes {
 	return Opcodes{header}.Add(codes...).Add(elemCode).Add(end)
 }
 
-type ArrayCode struct {
+type OrderedObjectCode struct {
 	typ   *runtime.Type
 	value Code
 }
 
+func (c *OrderedObjectCode) Kind() CodeKind {
+	return CodeKindOrderedObject
+}
+
+func (c *OrderedObjectCode) ToOpcode(ctx *compileContext) Opcodes {
+	// header => key => value => elem => end
+	//            ^                 |
+	//            |_________________|
+	header := newOrderedObjectHeaderCode(ctx, c.typ)
+	ctx.incIndex()
+
+	ctx.incIndent()
+	key := newOrderedObjectKeyCode(ctx, c.typ, header)
+	ctx.incIndex()
+	valueCodes := c.value.ToOpcode(ctx)
+	ctx.decIndent()
+
+	elemCode := newOrderedObjectElemCode(ctx, c.typ, header)
+	ctx.incIndex()
+
+	end := newOpCode(ctx, c.typ, OpOrderedObjectEnd)
+	ctx.incIndex()
+	header.End = end
+	header.Next = key
+	key.Next = valueCodes.First()
+	valueCodes.Last().Next = elemCode
+	elemCode.Next = key
+	elemCode.End = end
+	return Opcodes{header, key}.Add(valueCodes...).Add(elemCode).Add(end)
+}
+
+type ChanCode struct {
+	typ   *runtime.Type
+	value Code
+}
+
+func (c *ChanCode) Kind() CodeKind {
+	return CodeKindChan
+}
+
+func (c *ChanCode) ToOpcode(ctx *compileContext) Opcodes {
+	// header => opcode => elem => end
+	//             ^        |
+	//             |________|
+	header := newChanHeaderCode(ctx, c.typ)
+	ctx.incIndex()
+
+	ctx.incIndent()
+	codes := c.value.ToOpcode(ctx)
+	ctx.decIndent()
+
+	codes.First().Flags |= IndirectFlags
+	elemCode := newChanElemCode(ctx, c.typ, header)
+	ctx.incIndex()
+	end := newOpCode(ctx, c.typ, OpChanEnd)
+	ctx.incIndex()
+	header.End = end
+	header.Next = codes.First()
+	codes.Last().Next = elemCode
+	elemCode.Next = codes.First()
+	elemCode.End = end
+	return Opcodes{header}.Add(codes...).Add(elemCode).Add(end)
+}
+
+type ArrayCode struct {
+	typ         *runtime.Type
+	value       Code
+	isByteArray bool
+}
+
 func (c *ArrayCode) Kind() CodeKind {
 	return CodeKindArray
 }
@@ -263,6 +360,9 @@ func (c *ArrayCode) ToOpcode(ctx *compileContext) Opcodes {
 	size := elem.Size()
 
 	header := newArrayHeaderCode(ctx, c.typ, alen)
+	if c.isByteArray {
+		header.Flags |= ByteArrayFlags
+	}
 	ctx.incIndex()
 
 	ctx.incIndent()
@@ -389,6 +489,9 @@ func (c *StructCode) ToOpcode(ctx *compileContext) Opcodes {
 				code.Flags |= IndirectFlags
 			}
 		}
+		if isFirstField && !c.isIndirect {
+			convertDirectPtrValueOp(fieldCodes.First())
+		}
 		firstField := fieldCodes.First()
 		if len(codes) > 0 {
 			codes.Last().Next = firstField
@@ -462,6 +565,9 @@ func (c *StructCode) ToAnonymousOpcode(ctx *compileContext) Opcodes {
 				code.Flags |= IndirectFlags
 			}
 		}
+		if isFirstField && !c.isIndirect {
+			convertDirectPtrValueOp(fieldCodes.First())
+		}
 		firstField := fieldCodes.First()
 		if len(codes) > 0 {
 			codes.Last().Next = firstField
@@ -484,6 +590,22 @@ func (c *StructCode) ToAnonymousOpcode(ctx *compileContext) Opcodes {
 	return codes
 }
 
+// convertDirectPtrValueOp removes a dereference from the value operation of the pointer field of a struct
+// that is stored directly in the interface value.
+// In that case, the struct head passes the pointer itself to the value operation instead of its address.
+func convertDirectPtrValueOp(head *Opcode) {
+	if !head.Op.IsMultipleOpHead() {
+		return
+	}
+	value := head.Next
+	switch value.Op {
+	case OpTimePtr, OpInterfacePtr, OpOrderedObjectPtr:
+		if value.PtrNum > 0 {
+			value.PtrNum--
+		}
+	}
+}
+
 func (c *StructCode) removeFieldsByTags(tags runtime.StructTags) {
 	fields := make([]*StructFieldCode, 0, len(c.fields))
 	for _, field := range c.fields {
@@ -618,13 +740,25 @@ func (c *StructFieldCode) addStructEndCode(ctx *compileContext, codes Opcodes) O
 }
 
 func (c *StructFieldCode) structKey(ctx *compileContext) string {
-	if ctx.escapeKey {
-		rctx := &RuntimeContext{Option: &Option{Flag: HTMLEscapeOption}}
+	if !ctx.hasNonASCIIKey && !isASCII(c.key) {
+		ctx.hasNonASCIIKey = true
+	}
+	if ctx.escapeKey != 0 {
+		rctx := &RuntimeContext{Option: &Option{Flag: ctx.escapeKey}}
 		return fmt.Sprintf(`%s:`, string(AppendString(rctx, []byte{}, c.key)))
 	}
 	return fmt.Sprintf(`"%s":`, c.key)
 }
 
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *StructFieldCode) flags() OpFlags {
 	var flags OpFlags
 	if c.isTaggedKey {
@@ -645,9 +779,28 @@ func (c *StructFieldCode) flags() OpFlags {
 	if c.isAnonymous {
 		flags |= AnonymousKeyFlags
 	}
+	if c.tag.Precision >= 0 && (isFloatCode(c.value) || isBigNumberCode(c.value)) {
+		flags |= FloatPrecisionFlags
+	}
+	if code, ok := c.value.(*MarshalJSONCode); ok && code.isBigNumber {
+		flags |= BigNumberFlags
+		if c.tag.IsString {
+			flags |= BigNumberStringFlags
+		}
+	}
+	if c.tag.IsNilEmpty {
+		flags |= NilAsEmptyFlags
+	}
 	return flags
 }
 
+func (c *StructFieldCode) floatPrecision() uint8 {
+	if c.tag.Precision < 0 {
+		return 0
+	}
+	return uint8(c.tag.Precision)
+}
+
 func (c *StructFieldCode) toValueOpcodes(ctx *compileContext) Opcodes {
 	if c.isAnonymous {
 		anonymCode, ok := c.value.(AnonymousCode)
@@ -655,7 +808,23 @@ func (c *StructFieldCode) toValueOpcodes(ctx *compileContext) Opcodes {
 			return anonymCode.ToAnonymousOpcode(ctx)
 		}
 	}
-	return c.value.ToOpcode(ctx)
+	codes := c.value.ToOpcode(ctx)
+	codes.First().BytesEnc = c.bytesEncoding()
+	if c.tag.IsNilEmpty {
+		// also applies to the slices and maps nested in the field value
+		for _, code := range codes {
+			code.Flags |= NilAsEmptyFlags
+		}
+	}
+	return codes
+}
+
+func (c *StructFieldCode) bytesEncoding() uint8 {
+	if !isBytesCode(c.value) {
+		return 0
+	}
+	enc, _ := runtime.BytesEncodingByName(c.tag.Format)
+	return uint8(enc)
 }
 
 func (c *StructFieldCode) ToOpcode(ctx *compileContext, isFirstField, isEndField bool) Opcodes {
@@ -668,6 +837,8 @@ func (c *StructFieldCode) ToOpcode(ctx *compileContext, isFirstField, isEndField
 		DisplayIdx: ctx.opcodeIndex,
 		Indent:     ctx.indent,
 		DisplayKey: c.key,
+		Precision:  c.floatPrecision(),
+		BytesEnc:   c.bytesEncoding(),
 	}
 	ctx.incIndex()
 	valueCodes := c.toValueOpcodes(ctx)
@@ -699,6 +870,8 @@ func (c *StructFieldCode) ToAnonymousOpcode(ctx *compileContext, isFirstField, i
 		DisplayIdx: ctx.opcodeIndex,
 		Indent:     ctx.indent,
 		DisplayKey: c.key,
+		Precision:  c.floatPrecision(),
+		BytesEnc:   c.bytesEncoding(),
 	}
 	ctx.incIndex()
 	valueCodes := c.toValueOpcodes(ctx)
@@ -708,6 +881,52 @@ func (c *StructFieldCode) ToAnonymousOpcode(ctx *compileContext, isFirstField, i
 	return c.fieldOpcodes(ctx, field, valueCodes)
 }
 
+func isFloatCode(value Code) bool {
+	switch value.Kind() {
+	case CodeKindFloat:
+		return true
+	case CodeKindPtr:
+		return isFloatCode(value.(*PtrCode).value)
+	default:
+		return false
+	}
+}
+
+func isBigNumberCode(value Code) bool {
+	code, ok := value.(*MarshalJSONCode)
+	return ok && code.isBigNumber
+}
+
+func isBytesCode(value Code) bool {
+	switch value.Kind() {
+	case CodeKindBytes:
+		return true
+	case CodeKindArray:
+		return value.(*ArrayCode).isByteArray
+	case CodeKindPtr:
+		return isBytesCode(value.(*PtrCode).value)
+	default:
+		return false
+	}
+}
+
+// setTimeFormat applies the format option of the struct tag to the time.Time values of code,
+// including those in the slices, the arrays and the maps.
+func setTimeFormat(code Code, format string) {
+	switch c := code.(type) {
+	case *TimeCode:
+		c.format = format
+	case *PtrCode:
+		setTimeFormat(c.value, format)
+	case *SliceCode:
+		setTimeFormat(c.value, format)
+	case *ArrayCode:
+		setTimeFormat(c.value, format)
+	case *MapCode:
+		setTimeFormat(c.value, format)
+	}
+}
+
 func isEnableStructEndOptimization(value Code) bool {
 	switch value.Kind() {
 	case CodeKindInt,
@@ -753,6 +972,7 @@ type MarshalJSONCode struct {
 	isAddrForMarshaler bool
 	isNilableType      bool
 	isMarshalerContext bool
+	isBigNumber        bool
 }
 
 func (c *MarshalJSONCode) Kind() CodeKind {
@@ -767,6 +987,9 @@ func (c *MarshalJSONCode) ToOpcode(ctx *compileContext) Opcodes {
 	if c.isMarshalerContext {
 		code.Flags |= MarshalerContextFlags
 	}
+	if c.isBigNumber {
+		code.Flags |= BigNumberFlags
+	}
 	if c.isNilableType {
 		code.Flags |= IsNilableTypeFlags
 	} else {
@@ -868,6 +1091,12 @@ func convertPtrOp(code *Opcode) OpType {
 		return OpMarshalTextPtr
 	case OpInterface:
 		return OpInterfacePtr
+	case OpOrderedObject:
+		return OpOrderedObjectPtr
+	case OpTime:
+		return OpTimePtr
+	case OpChan:
+		return OpChanPtr
 	case OpRecursive:
 		return OpRecursivePtr
 	}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding"
 	"encoding/json"
+	"math/big"
 	"reflect"
-	"sync/atomic"
+	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/errors"
@@ -21,8 +23,15 @@ var (
 	marshalJSONContextType = reflect.TypeOf((*marshalerContext)(nil)).Elem()
 	marshalTextType        = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 	jsonNumberType         = reflect.TypeOf(json.Number(""))
+	bigIntPtrType          = runtime.Type2RType(reflect.TypeOf((*big.Int)(nil)))
+	bigFloatPtrType        = runtime.Type2RType(reflect.TypeOf((*big.Float)(nil)))
+	bigRatPtrType          = runtime.Type2RType(reflect.TypeOf((*big.Rat)(nil)))
+	orderedObjectType      = runtime.Type2RType(reflect.TypeOf(runtime.OrderedObject{}))
+	timeType               = runtime.Type2RType(reflect.TypeOf(time.Time{}))
 	cachedOpcodeSets       []*OpcodeSet
-	cachedOpcodeMap        unsafe.Pointer // map[uintptr]*OpcodeSet
+	cachedOpcodeMap        runtime.TypeCache // *OpcodeSet for the types outside of typeAddr
+	cachedTypesMu          sync.Mutex
+	cachedTypes            = map[*runtime.Type]struct{}{}
 	typeAddr               *runtime.TypeAddr
 )
 
@@ -34,33 +43,43 @@ func init() {
 	cachedOpcodeSets = make([]*OpcodeSet, typeAddr.AddrRange>>typeAddr.AddrShift)
 }
 
-func loadOpcodeMap() map[uintptr]*OpcodeSet {
-	p := atomic.LoadPointer(&cachedOpcodeMap)
-	return *(*map[uintptr]*OpcodeSet)(unsafe.Pointer(&p))
+// addCachedType records typ as the type whose OpcodeSet is stored in cachedOpcodeSets.
+func addCachedType(typ *runtime.Type) {
+	cachedTypesMu.Lock()
+	cachedTypes[typ] = struct{}{}
+	cachedTypesMu.Unlock()
 }
 
-func storeOpcodeSet(typ uintptr, set *OpcodeSet, m map[uintptr]*OpcodeSet) {
-	newOpcodeMap := make(map[uintptr]*OpcodeSet, len(m)+1)
-	newOpcodeMap[typ] = set
-
-	for k, v := range m {
-		newOpcodeMap[k] = v
+// CachedTypes returns the types whose OpcodeSet is already compiled by CompileToGetCodeSet.
+func CachedTypes() []*runtime.Type {
+	cachedTypesMu.Lock()
+	defer cachedTypesMu.Unlock()
+	types := make([]*runtime.Type, 0, len(cachedTypes))
+	for typ := range cachedTypes {
+		types = append(types, typ)
 	}
+	cachedOpcodeMap.Range(func(typ *runtime.Type, _ interface{}) {
+		types = append(types, typ)
+	})
+	return types
+}
 
-	atomic.StorePointer(&cachedOpcodeMap, *(*unsafe.Pointer)(unsafe.Pointer(&newOpcodeMap)))
+// SetCacheLimit bounds the number of the OpcodeSets cached for the types outside of the range of the type addresses,
+// such as the types created by reflect.StructOf. n <= 0 means unbounded.
+func SetCacheLimit(n int) {
+	cachedOpcodeMap.SetLimit(n)
 }
 
 func compileToGetCodeSetSlowPath(typeptr uintptr) (*OpcodeSet, error) {
-	opcodeMap := loadOpcodeMap()
-	if codeSet, exists := opcodeMap[typeptr]; exists {
-		return codeSet, nil
+	typ := *(**runtime.Type)(unsafe.Pointer(&typeptr))
+	if codeSet, exists := cachedOpcodeMap.Get(typ); exists {
+		return codeSet.(*OpcodeSet), nil
 	}
 	codeSet, err := newCompiler().compile(typeptr)
 	if err != nil {
 		return nil, err
 	}
-	storeOpcodeSet(typeptr, codeSet, opcodeMap)
-	return codeSet, nil
+	return cachedOpcodeMap.Store(typ, codeSet).(*OpcodeSet), nil
 }
 
 type Compiler struct {
@@ -80,17 +99,18 @@ func (c *Compiler) compile(typeptr uintptr) (*OpcodeSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	noescapeKeyCode := c.codeToOpcode(&compileContext{
+	noescapeKeyCtx := &compileContext{
 		structTypeToCodes: map[uintptr]Opcodes{},
 		recursiveCodes:    &Opcodes{},
-	}, typ, code)
+	}
+	noescapeKeyCode := c.codeToOpcode(noescapeKeyCtx, typ, code)
 	if err := noescapeKeyCode.Validate(); err != nil {
 		return nil, err
 	}
 	escapeKeyCode := c.codeToOpcode(&compileContext{
 		structTypeToCodes: map[uintptr]Opcodes{},
 		recursiveCodes:    &Opcodes{},
-		escapeKey:         true,
+		escapeKey:         HTMLEscapeOption,
 	}, typ, code)
 	noescapeKeyCode = copyOpcode(noescapeKeyCode)
 	escapeKeyCode = copyOpcode(escapeKeyCode)
@@ -98,20 +118,38 @@ func (c *Compiler) compile(typeptr uintptr) (*OpcodeSet, error) {
 	setTotalLengthToInterfaceOp(escapeKeyCode)
 	interfaceNoescapeKeyCode := copyToInterfaceOpcode(noescapeKeyCode)
 	interfaceEscapeKeyCode := copyToInterfaceOpcode(escapeKeyCode)
+
+	// the keys differ from the unescaped ones under EscapeASCIIOption only if they have non-ASCII characters.
+	asciiEscapeKeyCode := noescapeKeyCode
+	interfaceASCIIEscapeKeyCode := interfaceNoescapeKeyCode
+	if noescapeKeyCtx.hasNonASCIIKey {
+		asciiEscapeKeyCode = c.codeToOpcode(&compileContext{
+			structTypeToCodes: map[uintptr]Opcodes{},
+			recursiveCodes:    &Opcodes{},
+			escapeKey:         EscapeASCIIOption,
+		}, typ, code)
+		asciiEscapeKeyCode = copyOpcode(asciiEscapeKeyCode)
+		setTotalLengthToInterfaceOp(asciiEscapeKeyCode)
+		interfaceASCIIEscapeKeyCode = copyToInterfaceOpcode(asciiEscapeKeyCode)
+	}
 	codeLength := noescapeKeyCode.TotalLength()
 	return &OpcodeSet{
-		Type:                     typ,
-		NoescapeKeyCode:          noescapeKeyCode,
-		EscapeKeyCode:            escapeKeyCode,
-		InterfaceNoescapeKeyCode: interfaceNoescapeKeyCode,
-		InterfaceEscapeKeyCode:   interfaceEscapeKeyCode,
-		CodeLength:               codeLength,
-		EndCode:                  ToEndCode(interfaceNoescapeKeyCode),
+		Type:                        typ,
+		NoescapeKeyCode:             noescapeKeyCode,
+		EscapeKeyCode:               escapeKeyCode,
+		ASCIIEscapeKeyCode:          asciiEscapeKeyCode,
+		InterfaceNoescapeKeyCode:    interfaceNoescapeKeyCode,
+		InterfaceEscapeKeyCode:      interfaceEscapeKeyCode,
+		InterfaceASCIIEscapeKeyCode: interfaceASCIIEscapeKeyCode,
+		CodeLength:                  codeLength,
+		EndCode:                     ToEndCode(interfaceNoescapeKeyCode),
 	}, nil
 }
 
 func (c *Compiler) typeToCode(typ *runtime.Type) (Code, error) {
 	switch {
+	case typ == timeType:
+		return c.timeCode(typ, false)
 	case c.implementsMarshalJSON(typ):
 		return c.marshalJSONCode(typ)
 	case c.implementsMarshalText(typ):
@@ -125,13 +163,23 @@ func (c *Compiler) typeToCode(typ *runtime.Type) (Code, error) {
 		isPtr = true
 	}
 	switch {
+	case typ == timeType:
+		return c.timeCode(typ, isPtr)
 	case c.implementsMarshalJSON(typ):
 		return c.marshalJSONCode(orgType)
 	case c.implementsMarshalText(typ):
 		return c.marshalTextCode(orgType)
+	case isIteratorType(typ):
+		if isPtr {
+			return c.ptrCode(runtime.PtrTo(typ))
+		}
+		return c.chanCode(typ)
 	}
 	switch typ.Kind() {
 	case reflect.Slice:
+		if typ == orderedObjectType {
+			return c.orderedObjectCode(typ)
+		}
 		elem := typ.Elem()
 		if elem.Kind() == reflect.Uint8 {
 			p := runtime.PtrTo(elem)
@@ -187,6 +235,8 @@ func (c *Compiler) typeToCode(typ *runtime.Type) (Code, error) {
 
 func (c *Compiler) typeToCodeWithPtr(typ *runtime.Type, isPtr bool) (Code, error) {
 	switch {
+	case typ == timeType:
+		return c.timeCode(typ, false)
 	case c.implementsMarshalJSON(typ):
 		return c.marshalJSONCode(typ)
 	case c.implementsMarshalText(typ):
@@ -196,6 +246,9 @@ func (c *Compiler) typeToCodeWithPtr(typ *runtime.Type, isPtr bool) (Code, error
 	case reflect.Ptr:
 		return c.ptrCode(typ)
 	case reflect.Slice:
+		if typ == orderedObjectType {
+			return c.orderedObjectCode(typ)
+		}
 		elem := typ.Elem()
 		if elem.Kind() == reflect.Uint8 {
 			p := runtime.PtrTo(elem)
@@ -208,6 +261,10 @@ func (c *Compiler) typeToCodeWithPtr(typ *runtime.Type, isPtr bool) (Code, error
 		return c.arrayCode(typ)
 	case reflect.Map:
 		return c.mapCode(typ)
+	case reflect.Chan, reflect.Func:
+		if isIteratorType(typ) {
+			return c.chanCode(typ)
+		}
 	case reflect.Struct:
 		return c.structCode(typ, isPtr)
 	case reflect.Interface:
@@ -373,6 +430,11 @@ func (c *Compiler) bytesCode(typ *runtime.Type, isPtr bool) (*BytesCode, error)
 	return &BytesCode{typ: typ, isPtr: isPtr}, nil
 }
 
+//nolint:unparam
+func (c *Compiler) timeCode(typ *runtime.Type, isPtr bool) (*TimeCode, error) {
+	return &TimeCode{typ: typ, isPtr: isPtr}, nil
+}
+
 //nolint:unparam
 func (c *Compiler) interfaceCode(typ *runtime.Type, isPtr bool) (*InterfaceCode, error) {
 	return &InterfaceCode{typ: typ, isPtr: isPtr}, nil
@@ -385,6 +447,7 @@ func (c *Compiler) marshalJSONCode(typ *runtime.Type) (*MarshalJSONCode, error)
 		isAddrForMarshaler: c.isPtrMarshalJSONType(typ),
 		isNilableType:      c.isNilableType(typ),
 		isMarshalerContext: typ.Implements(marshalJSONContextType) || runtime.PtrTo(typ).Implements(marshalJSONContextType),
+		isBigNumber:        isBigNumberPtrType(typ) || isBigNumberPtrType(runtime.PtrTo(typ)),
 	}, nil
 }
 
@@ -422,6 +485,14 @@ func (c *Compiler) sliceCode(typ *runtime.Type) (*SliceCode, error) {
 	return &SliceCode{typ: typ, value: code}, nil
 }
 
+func (c *Compiler) orderedObjectCode(typ *runtime.Type) (*OrderedObjectCode, error) {
+	value, err := c.interfaceCode(runtime.Type2RType(typ.Elem().Field(1).Type), false)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderedObjectCode{typ: typ, value: value}, nil
+}
+
 func (c *Compiler) arrayCode(typ *runtime.Type) (*ArrayCode, error) {
 	elem := typ.Elem()
 	code, err := c.listElemCode(elem)
@@ -432,7 +503,20 @@ func (c *Compiler) arrayCode(typ *runtime.Type) (*ArrayCode, error) {
 		structCode := code.(*StructCode)
 		structCode.enableIndirect()
 	}
-	return &ArrayCode{typ: typ, value: code}, nil
+	isByteArray := elem.Kind() == reflect.Uint8 && code.Kind() == CodeKindUint
+	return &ArrayCode{typ: typ, value: code, isByteArray: isByteArray}, nil
+}
+
+func (c *Compiler) chanCode(typ *runtime.Type) (*ChanCode, error) {
+	code, err := c.listElemCode(iteratorElemType(typ))
+	if err != nil {
+		return nil, err
+	}
+	if code.Kind() == CodeKindStruct {
+		structCode := code.(*StructCode)
+		structCode.enableIndirect()
+	}
+	return &ChanCode{typ: typ, value: code}, nil
 }
 
 func (c *Compiler) mapCode(typ *runtime.Type) (*MapCode, error) {
@@ -650,9 +734,12 @@ func (c *Compiler) structFieldCode(structCode *StructCode, tag *runtime.StructTa
 			return nil, err
 		}
 		switch code.Kind() {
-		case CodeKindPtr, CodeKindInterface:
+		case CodeKindPtr, CodeKindInterface, CodeKindOrderedObject:
 			fieldCode.isNextOpPtrType = true
 		}
+		if tag.Format != "" {
+			setTimeFormat(code, tag.Format)
+		}
 		fieldCode.value = code
 	}
 	return fieldCode, nil
@@ -839,7 +926,14 @@ func (c *Compiler) isNilableType(typ *runtime.Type) bool {
 }
 
 func (c *Compiler) implementsMarshalJSONType(typ *runtime.Type) bool {
-	return typ.Implements(marshalJSONType) || typ.Implements(marshalJSONContextType)
+	return typ.Implements(marshalJSONType) || typ.Implements(marshalJSONContextType) || isBigNumberPtrType(typ)
+}
+
+// isBigNumberPtrType reports whether typ is *big.Int, *big.Float or *big.Rat.
+// These are treated like json.Marshaler implementations so that every compile path handles them in the same way,
+// but are written as bare numbers by AppendBigNumber instead of calling MarshalJSON or MarshalText.
+func isBigNumberPtrType(typ *runtime.Type) bool {
+	return typ == bigIntPtrType || typ == bigFloatPtrType || typ == bigRatPtrType
 }
 
 func (c *Compiler) isPtrMarshalJSONType(typ *runtime.Type) bool {
@@ -16,5 +16,6 @@ func CompileToGetCodeSet(typeptr uintptr) (*OpcodeSet, error) {
 		return nil, err
 	}
 	cachedOpcodeSets[index] = codeSet
+	addCachedType(codeSet.Type)
 	return codeSet, nil
 }
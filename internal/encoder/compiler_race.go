@@ -28,5 +28,6 @@ func CompileToGetCodeSet(typeptr uintptr) (*OpcodeSet, error) {
 	setsMu.Lock()
 	cachedOpcodeSets[index] = codeSet
 	setsMu.Unlock()
+	addCachedType(codeSet.Type)
 	return codeSet, nil
 }
@@ -2,6 +2,7 @@ package encoder
 
 import (
 	"context"
+	"io"
 	"sync"
 	"unsafe"
 
@@ -12,7 +13,8 @@ type compileContext struct {
 	opcodeIndex       uint32
 	ptrIndex          int
 	indent            uint32
-	escapeKey         bool
+	escapeKey         OptionFlag // escape policy for struct field keys ( zero writes them as is )
+	hasNonASCIIKey    bool
 	structTypeToCodes map[uintptr]Opcodes
 	recursiveCodes    *Opcodes
 }
@@ -79,6 +81,13 @@ type RuntimeContext struct {
 	Prefix     []byte
 	IndentStr  []byte
 	Option     *Option
+
+	// Writer receives the encoded bytes flushed by Flush when FlushOption is specified.
+	Writer         io.Writer
+	FlushThreshold int
+	// SortedMapDepth is the number of sorted maps being encoded.
+	// Flush is suspended while it is positive, because a sorted map rewrites the buffer from its beginning.
+	SortedMapDepth int
 }
 
 func (c *RuntimeContext) Init(p uintptr, codelen int) {
@@ -96,6 +105,19 @@ func (c *RuntimeContext) Ptr() uintptr {
 	return uintptr(header.Data)
 }
 
+// Flush writes b to c.Writer except the last two bytes, and returns the rest of b.
+// The last bytes are kept since the VM rewrites the trailing comma and newline when it closes an array or an object.
+func (c *RuntimeContext) Flush(b []byte) ([]byte, error) {
+	if c.SortedMapDepth > 0 || len(b) <= 2 {
+		return b, nil
+	}
+	n := len(b) - 2
+	if _, err := c.Writer.Write(b[:n]); err != nil {
+		return nil, err
+	}
+	return append(b[:0], b[n:]...), nil
+}
+
 func TakeRuntimeContext() *RuntimeContext {
 	return runtimeContextPool.Get().(*RuntimeContext)
 }
@@ -3,14 +3,16 @@ package encoder
 import (
 	"bytes"
 	"encoding"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/errors"
@@ -94,13 +96,15 @@ func (t OpType) IsMultipleOpField() bool {
 }
 
 type OpcodeSet struct {
-	Type                     *runtime.Type
-	NoescapeKeyCode          *Opcode
-	EscapeKeyCode            *Opcode
-	InterfaceNoescapeKeyCode *Opcode
-	InterfaceEscapeKeyCode   *Opcode
-	CodeLength               int
-	EndCode                  *Opcode
+	Type                        *runtime.Type
+	NoescapeKeyCode             *Opcode
+	EscapeKeyCode               *Opcode
+	ASCIIEscapeKeyCode          *Opcode // struct keys escaped by EscapeASCIIOption
+	InterfaceNoescapeKeyCode    *Opcode
+	InterfaceEscapeKeyCode      *Opcode
+	InterfaceASCIIEscapeKeyCode *Opcode
+	CodeLength                  int
+	EndCode                     *Opcode
 }
 
 type CompiledCode struct {
@@ -189,6 +193,13 @@ func ErrUnsupportedFloat(v float64) *errors.UnsupportedValueError {
 	}
 }
 
+func ErrUnsupportedFloat32(v float32) *errors.UnsupportedValueError {
+	return &errors.UnsupportedValueError{
+		Value: reflect.ValueOf(v),
+		Str:   strconv.FormatFloat(float64(v), 'g', -1, 32),
+	}
+}
+
 func ErrMarshalerWithCode(code *Opcode, err error) *errors.MarshalerError {
 	return &errors.MarshalerError{
 		Type: runtime.RType2Type(code.Type),
@@ -222,6 +233,58 @@ func (m *Mapslice) Swap(i, j int) {
 	m.Items[i], m.Items[j] = m.Items[j], m.Items[i]
 }
 
+// Sort sorts the encoded map items by the order specified with MapKeyOrderOption,
+// or by the byte order of the encoded keys by default.
+func (m *Mapslice) Sort(ctx *RuntimeContext) {
+	if (ctx.Option.Flag&MapKeyOrderOption) == 0 || ctx.Option.MapKeyLess == nil {
+		sort.Sort(m)
+		return
+	}
+	keys := make([]string, len(m.Items))
+	for i, item := range m.Items {
+		keys[i] = decodeMapKey(item.Key)
+	}
+	sort.Stable(&mapsliceByKey{items: m.Items, keys: keys, less: ctx.Option.MapKeyLess})
+}
+
+type mapsliceByKey struct {
+	items []MapItem
+	keys  []string
+	less  func(a, b string) bool
+}
+
+func (m *mapsliceByKey) Len() int {
+	return len(m.items)
+}
+
+func (m *mapsliceByKey) Less(i, j int) bool {
+	return m.less(m.keys[i], m.keys[j])
+}
+
+func (m *mapsliceByKey) Swap(i, j int) {
+	m.items[i], m.items[j] = m.items[j], m.items[i]
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+}
+
+// decodeMapKey returns the original string of the encoded map key.
+// The encoded key may be surrounded by color sequences, separators and indentation,
+// but always contains exactly one quoted JSON string.
+func decodeMapKey(key []byte) string {
+	start := bytes.IndexByte(key, '"')
+	end := bytes.LastIndexByte(key, '"')
+	if start < 0 || end <= start {
+		return string(key)
+	}
+	quoted := key[start : end+1]
+	if bytes.IndexByte(quoted, '\\') < 0 {
+		return string(quoted[1 : len(quoted)-1])
+	}
+	if unquoted, err := strconv.Unquote(string(quoted)); err == nil {
+		return unquoted
+	}
+	return string(quoted[1 : len(quoted)-1])
+}
+
 type MapContext struct {
 	Pos   []int
 	Slice *Mapslice
@@ -272,11 +335,50 @@ func MapIterNext(it unsafe.Pointer)
 //go:noescape
 func MapLen(m unsafe.Pointer) int
 
-func AppendByteSlice(_ *RuntimeContext, b []byte, src []byte) []byte {
-	if src == nil {
+// BytesEncoding returns the encoding for []byte or [N]byte value of code.
+// The `format` option of the struct tag takes precedence over BytesEncodingOption.
+func BytesEncoding(ctx *RuntimeContext, code *Opcode) runtime.BytesEncoding {
+	if code.BytesEnc != 0 {
+		return runtime.BytesEncoding(code.BytesEnc)
+	}
+	if (ctx.Option.Flag & BytesEncodingOption) != 0 {
+		return ctx.Option.BytesEncoding
+	}
+	return runtime.BytesEncodingDefault
+}
+
+// IsEncodedByteArray reports whether [N]byte value of code is encoded in the same way as []byte
+// instead of an array of numbers.
+func IsEncodedByteArray(ctx *RuntimeContext, code *Opcode) bool {
+	enc := BytesEncoding(ctx, code)
+	return enc != runtime.BytesEncodingDefault && enc != runtime.BytesEncodingArray
+}
+
+// IsNilAsEmpty reports whether nil slice or map value of code is encoded as an empty container instead of null.
+func IsNilAsEmpty(ctx *RuntimeContext, code *Opcode) bool {
+	return (code.Flags&NilAsEmptyFlags) != 0 || (ctx.Option.Flag&NilAsEmptyOption) != 0
+}
+
+func AppendByteSlice(ctx *RuntimeContext, code *Opcode, b []byte, src []byte) []byte {
+	if src == nil && !IsNilAsEmpty(ctx, code) {
 		return append(b, `null`...)
 	}
-	encodedLen := base64.StdEncoding.EncodedLen(len(src))
+	enc := BytesEncoding(ctx, code)
+	switch enc {
+	case runtime.BytesEncodingHex:
+		return appendHex(b, src)
+	case runtime.BytesEncodingArray:
+		b = append(b, '[')
+		for i, c := range src {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendUint(b, uint64(c), 10)
+		}
+		return append(b, ']')
+	}
+	encoding := enc.Base64()
+	encodedLen := encoding.EncodedLen(len(src))
 	b = append(b, '"')
 	pos := len(b)
 	remainLen := cap(b[pos:])
@@ -286,12 +388,40 @@ func AppendByteSlice(_ *RuntimeContext, b []byte, src []byte) []byte {
 	} else {
 		buf = make([]byte, encodedLen)
 	}
-	base64.StdEncoding.Encode(buf, src)
+	encoding.Encode(buf, src)
 	return append(append(b, buf...), '"')
 }
 
-func AppendFloat32(_ *RuntimeContext, b []byte, v float32) []byte {
+func AppendByteSliceIndent(ctx *RuntimeContext, code *Opcode, b []byte, src []byte) []byte {
+	if len(src) == 0 || BytesEncoding(ctx, code) != runtime.BytesEncodingArray {
+		return AppendByteSlice(ctx, code, b, src)
+	}
+	b = append(b, '[', '\n')
+	for i, c := range src {
+		if i > 0 {
+			b = append(b, ',', '\n')
+		}
+		b = AppendIndent(ctx, b, code.Indent+1)
+		b = strconv.AppendUint(b, uint64(c), 10)
+	}
+	b = append(b, '\n')
+	b = AppendIndent(ctx, b, code.Indent)
+	return append(b, ']')
+}
+
+func appendHex(b []byte, src []byte) []byte {
+	b = append(b, '"')
+	for _, c := range src {
+		b = append(b, hex[c>>4], hex[c&0xf])
+	}
+	return append(b, '"')
+}
+
+func AppendFloat32(ctx *RuntimeContext, code *Opcode, b []byte, v float32) []byte {
 	f64 := float64(v)
+	if math.IsInf(f64, 0) || math.IsNaN(f64) {
+		return appendNonFiniteFloat(ctx, b, f64, 32)
+	}
 	abs := math.Abs(f64)
 	fmt := byte('f')
 	// Note: Must use float32 comparisons for underlying float32 value to get precise cutoffs right.
@@ -301,10 +431,17 @@ func AppendFloat32(_ *RuntimeContext, b []byte, v float32) []byte {
 			fmt = 'e'
 		}
 	}
-	return strconv.AppendFloat(b, f64, fmt, -1, 32)
+	if (ctx.Option.Flag&floatFormatOptions) == 0 && (code.Flags&FloatPrecisionFlags) == 0 {
+		return strconv.AppendFloat(b, f64, fmt, -1, 32)
+	}
+	fmt, prec := floatFormat(ctx, code, fmt)
+	return strconv.AppendFloat(b, f64, fmt, prec, 32)
 }
 
-func AppendFloat64(_ *RuntimeContext, b []byte, v float64) []byte {
+func AppendFloat64(ctx *RuntimeContext, code *Opcode, b []byte, v float64) []byte {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return appendNonFiniteFloat(ctx, b, v, 64)
+	}
 	abs := math.Abs(v)
 	fmt := byte('f')
 	// Note: Must use float32 comparisons for underlying float32 value to get precise cutoffs right.
@@ -313,7 +450,75 @@ func AppendFloat64(_ *RuntimeContext, b []byte, v float64) []byte {
 			fmt = 'e'
 		}
 	}
-	return strconv.AppendFloat(b, v, fmt, -1, 64)
+	if (ctx.Option.Flag&floatFormatOptions) == 0 && (code.Flags&FloatPrecisionFlags) == 0 {
+		return strconv.AppendFloat(b, v, fmt, -1, 64)
+	}
+	fmt, prec := floatFormat(ctx, code, fmt)
+	return strconv.AppendFloat(b, v, fmt, prec, 64)
+}
+
+// AppendFloat32String appends v as the quoted value of a field tagged with `string`.
+// Non-finite values allowed by the encode option are written as is, without additional quotes.
+func AppendFloat32String(ctx *RuntimeContext, code *Opcode, b []byte, v float32) []byte {
+	f64 := float64(v)
+	if (math.IsInf(f64, 0) || math.IsNaN(f64)) && (ctx.Option.Flag&nonFiniteFloatOptions) != 0 {
+		return appendNonFiniteFloat(ctx, b, f64, 32)
+	}
+	b = append(b, '"')
+	b = AppendFloat32(ctx, code, b, v)
+	return append(b, '"')
+}
+
+// AppendFloat64String appends v as the quoted value of a field tagged with `string`.
+// Non-finite values allowed by the encode option are written as is, without additional quotes.
+func AppendFloat64String(ctx *RuntimeContext, code *Opcode, b []byte, v float64) []byte {
+	if (math.IsInf(v, 0) || math.IsNaN(v)) && (ctx.Option.Flag&nonFiniteFloatOptions) != 0 {
+		return appendNonFiniteFloat(ctx, b, v, 64)
+	}
+	b = append(b, '"')
+	b = AppendFloat64(ctx, code, b, v)
+	return append(b, '"')
+}
+
+// IsUnsupportedFloat reports whether v is NaN or Inf and the encode option doesn't allow encoding them.
+func IsUnsupportedFloat(ctx *RuntimeContext, v float64) bool {
+	if !math.IsInf(v, 0) && !math.IsNaN(v) {
+		return false
+	}
+	return (ctx.Option.Flag & nonFiniteFloatOptions) == 0
+}
+
+func floatFormat(ctx *RuntimeContext, code *Opcode, fmt byte) (byte, int) {
+	switch {
+	case (ctx.Option.Flag & FloatExponentNeverOption) != 0:
+		fmt = 'f'
+	case (ctx.Option.Flag & FloatExponentAlwaysOption) != 0:
+		fmt = 'e'
+	}
+	prec := -1
+	if (code.Flags & FloatPrecisionFlags) != 0 {
+		prec = int(code.Precision)
+	} else if (ctx.Option.Flag & FloatPrecisionOption) != 0 {
+		prec = ctx.Option.FloatPrecision
+	}
+	return fmt, prec
+}
+
+func appendNonFiniteFloat(ctx *RuntimeContext, b []byte, v float64, bitSize int) []byte {
+	switch {
+	case (ctx.Option.Flag & NonFiniteFloatNullOption) != 0:
+		return append(b, "null"...)
+	case (ctx.Option.Flag & NonFiniteFloatStringOption) != 0:
+		switch {
+		case math.IsNaN(v):
+			return append(b, `"NaN"`...)
+		case v > 0:
+			return append(b, `"+Inf"`...)
+		default:
+			return append(b, `"-Inf"`...)
+		}
+	}
+	return strconv.AppendFloat(b, v, 'g', -1, bitSize)
 }
 
 func AppendBool(_ *RuntimeContext, b []byte, v bool) []byte {
@@ -356,6 +561,161 @@ func AppendNumber(_ *RuntimeContext, b []byte, n json.Number) ([]byte, error) {
 	return b, nil
 }
 
+// bigRatPrecision is the number of digits after the decimal point used for a *big.Rat
+// that has no finite decimal representation (e.g. 1/3) when neither the `precision` option
+// of the struct tag nor FloatPrecisionOption is set.
+const bigRatPrecision = 20
+
+var (
+	bigFloatExpMin = big.NewFloat(1e-6)
+	bigFloatExpMax = big.NewFloat(1e21)
+)
+
+// AppendBigNumber appends *big.Int, *big.Float or *big.Rat value as a bare JSON number,
+// or as a quoted number for a field tagged with `string`.
+// Nil values and non-finite values allowed by the encode option are written as is, without quotes.
+func AppendBigNumber(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}) ([]byte, error) {
+	if (code.Flags&BigNumberStringFlags) == 0 || isBareBigNumber(v) {
+		return appendBigNumber(ctx, code, b, v)
+	}
+	b = append(b, '"')
+	b, err := appendBigNumber(ctx, code, b, v)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '"'), nil
+}
+
+// isBareBigNumber reports whether v is written without quotes even if the field is tagged with `string`.
+func isBareBigNumber(v interface{}) bool {
+	switch n := v.(type) {
+	case *big.Int:
+		return n == nil
+	case *big.Float:
+		return n == nil || n.IsInf()
+	case *big.Rat:
+		return n == nil
+	}
+	return true
+}
+
+func appendBigNumber(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}) ([]byte, error) {
+	switch n := v.(type) {
+	case *big.Int:
+		if n == nil {
+			return AppendNull(ctx, b), nil
+		}
+		return n.Append(b, 10), nil
+	case *big.Float:
+		if n == nil {
+			return AppendNull(ctx, b), nil
+		}
+		if n.IsInf() {
+			f64, _ := n.Float64()
+			if (ctx.Option.Flag & nonFiniteFloatOptions) == 0 {
+				return nil, ErrUnsupportedFloat(f64)
+			}
+			return appendNonFiniteFloat(ctx, b, f64, 64), nil
+		}
+		// same cutoffs as AppendFloat64.
+		fmt := byte('f')
+		if abs := new(big.Float).Abs(n); abs.Sign() != 0 {
+			if abs.Cmp(bigFloatExpMin) < 0 || abs.Cmp(bigFloatExpMax) >= 0 {
+				fmt = 'e'
+			}
+		}
+		if (ctx.Option.Flag&floatFormatOptions) == 0 && (code.Flags&FloatPrecisionFlags) == 0 {
+			return n.Append(b, fmt, -1), nil
+		}
+		fmt, prec := floatFormat(ctx, code, fmt)
+		return n.Append(b, fmt, prec), nil
+	case *big.Rat:
+		if n == nil {
+			return AppendNull(ctx, b), nil
+		}
+		if n.IsInt() {
+			return n.Num().Append(b, 10), nil
+		}
+		if (code.Flags & FloatPrecisionFlags) != 0 {
+			return append(b, n.FloatString(int(code.Precision))...), nil
+		}
+		if (ctx.Option.Flag & FloatPrecisionOption) != 0 {
+			return append(b, n.FloatString(ctx.Option.FloatPrecision)...), nil
+		}
+		if prec, exact := ratDecimalPrec(n); exact {
+			return append(b, n.FloatString(prec)...), nil
+		}
+		return append(b, n.FloatString(bigRatPrecision)...), nil
+	}
+	return AppendNull(ctx, b), nil
+}
+
+// ratDecimalPrec returns the number of digits after the decimal point needed to represent r exactly,
+// and whether such a finite decimal representation exists (the denominator has no prime factors other than 2 and 5).
+func ratDecimalPrec(r *big.Rat) (int, bool) {
+	d := r.Denom()
+	twos := int(d.TrailingZeroBits())
+	rest := new(big.Int).Rsh(d, uint(twos))
+	five := big.NewInt(5)
+	var q, m big.Int
+	fives := 0
+	for {
+		q.QuoRem(rest, five, &m)
+		if m.Sign() != 0 {
+			break
+		}
+		rest.Set(&q)
+		fives++
+	}
+	if !rest.IsInt64() || rest.Int64() != 1 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+// TimeFormat returns the format for time.Time value of code.
+// The `format` option of the struct tag takes precedence over TimeFormatOption.
+func TimeFormat(ctx *RuntimeContext, code *Opcode) string {
+	if code.Key != "" {
+		return code.Key
+	}
+	if (ctx.Option.Flag & TimeFormatOption) != 0 {
+		return ctx.Option.TimeFormat
+	}
+	return ""
+}
+
+// AppendTime appends time.Time value without calling MarshalJSON.
+// Unix time formats are written as JSON number, and layouts are written as JSON string.
+// If no format is specified, the value is written in RFC 3339 format as time.Time.MarshalJSON does.
+func AppendTime(ctx *RuntimeContext, code *Opcode, b []byte, t time.Time) ([]byte, error) {
+	format := TimeFormat(ctx, code)
+	if format == "" {
+		if y := t.Year(); y < 0 || y >= 10000 {
+			// use the error of MarshalJSON for the value that cannot be represented in RFC 3339 format.
+			if _, err := t.MarshalJSON(); err != nil {
+				return nil, &errors.MarshalerError{Type: reflect.TypeOf(t), Err: err}
+			}
+		}
+		b = append(b, '"')
+		b = t.AppendFormat(b, time.RFC3339Nano)
+		return append(b, '"'), nil
+	}
+	if unit, ok := runtime.UnixTimeUnit(format); ok {
+		return strconv.AppendInt(b, unixTime(t, unit), 10), nil
+	}
+	buf := t.AppendFormat(ctx.MarshalBuf[:0], format)
+	ctx.MarshalBuf = buf
+	return AppendString(ctx, b, *(*string)(unsafe.Pointer(&buf))), nil
+}
+
+func unixTime(t time.Time, unit time.Duration) int64 {
+	return t.Unix()*int64(time.Second/unit) + int64(t.Nanosecond())/int64(unit)
+}
+
 func AppendMarshalJSON(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}) ([]byte, error) {
 	rv := reflect.ValueOf(v) // convert by dynamic interface type
 	if (code.Flags & AddrForMarshalerFlags) != 0 {
@@ -368,6 +728,9 @@ func AppendMarshalJSON(ctx *RuntimeContext, code *Opcode, b []byte, v interface{
 		}
 	}
 	v = rv.Interface()
+	if (code.Flags & BigNumberFlags) != 0 {
+		return AppendBigNumber(ctx, code, b, v)
+	}
 	var bb []byte
 	if (code.Flags & MarshalerContextFlags) != 0 {
 		marshaler, ok := v.(marshalerContext)
@@ -412,6 +775,9 @@ func AppendMarshalJSONIndent(ctx *RuntimeContext, code *Opcode, b []byte, v inte
 		}
 	}
 	v = rv.Interface()
+	if (code.Flags & BigNumberFlags) != 0 {
+		return AppendBigNumber(ctx, code, b, v)
+	}
 	var bb []byte
 	if (code.Flags & MarshalerContextFlags) != 0 {
 		marshaler, ok := v.(marshalerContext)
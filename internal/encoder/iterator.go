@@ -0,0 +1,94 @@
+package encoder
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// iteratorFuncTypes holds the func types registered by RegisterIterator.
+var iteratorFuncTypes sync.Map
+
+// RegisterIterator registers typ, a func type of the form func() (T, bool), as an iterator.
+// Values of the registered type are called until they report false, and the results are encoded as a JSON array.
+func RegisterIterator(typ reflect.Type) error {
+	if typ == nil || typ.Kind() != reflect.Func || typ.NumIn() != 0 || typ.NumOut() != 2 || typ.Out(1).Kind() != reflect.Bool {
+		return fmt.Errorf("json: iterator must be func() (T, bool) but got %s", typ)
+	}
+	iteratorFuncTypes.Store(uintptr(unsafe.Pointer(runtime.Type2RType(typ))), struct{}{})
+	return nil
+}
+
+// isIteratorType reports whether typ is encoded as a streamed JSON array.
+// Channels that can be received from and the registered iterator funcs are.
+func isIteratorType(typ *runtime.Type) bool {
+	switch typ.Kind() {
+	case reflect.Chan:
+		return typ.ChanDir()&reflect.RecvDir != 0
+	case reflect.Func:
+		_, exists := iteratorFuncTypes.Load(uintptr(unsafe.Pointer(typ)))
+		return exists
+	}
+	return false
+}
+
+func iteratorElemType(typ *runtime.Type) *runtime.Type {
+	if typ.Kind() == reflect.Func {
+		return runtime.Type2RType(typ.Out(0))
+	}
+	return typ.Elem()
+}
+
+// Iterator takes out the elements of a channel or an iterator func one by one.
+type Iterator struct {
+	ctx  *RuntimeContext
+	v    reflect.Value
+	elem reflect.Value
+}
+
+func NewIterator(ctx *RuntimeContext, code *Opcode, p uintptr) *Iterator {
+	v := reflect.ValueOf(PtrToInterface(code, p))
+	elem := reflect.New(runtime.RType2Type(iteratorElemType(code.Type))).Elem()
+	return &Iterator{ctx: ctx, v: v, elem: elem}
+}
+
+// Next returns the address of the next element.
+// It returns false when the channel is closed or the iterator func reports the end.
+// If the encoding runs with a context, receiving from the channel is canceled when the context is done.
+func (it *Iterator) Next() (uintptr, bool, error) {
+	var (
+		v  reflect.Value
+		ok bool
+	)
+	switch {
+	case it.v.Kind() == reflect.Func:
+		out := it.v.Call(nil)
+		v, ok = out[0], out[1].Bool()
+	case it.done() != nil:
+		chosen, recv, recvOK := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: it.v},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(it.done())},
+		})
+		if chosen == 1 {
+			return 0, false, it.ctx.Option.Context.Err()
+		}
+		v, ok = recv, recvOK
+	default:
+		v, ok = it.v.Recv()
+	}
+	if !ok {
+		return 0, false, nil
+	}
+	it.elem.Set(v)
+	return it.elem.UnsafeAddr(), true, nil
+}
+
+func (it *Iterator) done() <-chan struct{} {
+	if (it.ctx.Option.Flag&ContextOption) == 0 || it.ctx.Option.Context == nil {
+		return nil
+	}
+	return it.ctx.Option.Context.Done()
+}
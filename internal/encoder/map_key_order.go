@@ -0,0 +1,133 @@
+package encoder
+
+import (
+	"strconv"
+)
+
+// NaturalMapKeyLess compares map keys in natural order.
+// Runs of ASCII digits are compared by their numeric value, so that "item2" comes before "item10".
+// If two keys are equal in natural order ( e.g. "a01" and "a1" ), they are compared by byte order.
+func NaturalMapKeyLess(a, b string) bool {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ca, cb := a[i], b[j]
+		if isDigit(ca) && isDigit(cb) {
+			ni := i
+			for ni < len(a) && isDigit(a[ni]) {
+				ni++
+			}
+			nj := j
+			for nj < len(b) && isDigit(b[nj]) {
+				nj++
+			}
+			if c := compareDigits(a[i:ni], b[j:nj]); c != 0 {
+				return c < 0
+			}
+			i, j = ni, nj
+			continue
+		}
+		if ca != cb {
+			return ca < cb
+		}
+		i++
+		j++
+	}
+	if len(a)-i != len(b)-j {
+		return len(a)-i < len(b)-j
+	}
+	return a < b
+}
+
+// NumericMapKeyLess compares map keys by their numeric value.
+// Only keys written in the JSON number syntax ( e.g. not "NaN", "0x10" or "1_000" ) are numeric.
+// Keys that are not numbers are placed after numeric keys and are compared by byte order.
+func NumericMapKeyLess(a, b string) bool {
+	numA, numB := isNumberKey(a), isNumberKey(b)
+	switch {
+	case numA && numB:
+		// a number out of the float64 range is parsed as ±Inf, which still orders correctly.
+		fa, _ := strconv.ParseFloat(a, 64)
+		fb, _ := strconv.ParseFloat(b, 64)
+		if fa != fb {
+			return fa < fb
+		}
+		return a < b
+	case numA:
+		return true
+	case numB:
+		return false
+	}
+	return a < b
+}
+
+// isNumberKey reports whether s is a number in the JSON number syntax.
+func isNumberKey(s string) bool {
+	if s == "" {
+		return false
+	}
+	if s[0] == '-' {
+		s = s[1:]
+		if s == "" {
+			return false
+		}
+	}
+	switch {
+	case s[0] == '0':
+		s = s[1:]
+	case '1' <= s[0] && s[0] <= '9':
+		for s != "" && isDigit(s[0]) {
+			s = s[1:]
+		}
+	default:
+		return false
+	}
+	if len(s) >= 2 && s[0] == '.' && isDigit(s[1]) {
+		s = s[2:]
+		for s != "" && isDigit(s[0]) {
+			s = s[1:]
+		}
+	}
+	if len(s) >= 2 && (s[0] == 'e' || s[0] == 'E') {
+		s = s[1:]
+		if s[0] == '+' || s[0] == '-' {
+			s = s[1:]
+			if s == "" {
+				return false
+			}
+		}
+		for s != "" && isDigit(s[0]) {
+			s = s[1:]
+		}
+	}
+	return s == ""
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// compareDigits compares two runs of ASCII digits by their numeric value.
+func compareDigits(a, b string) int {
+	a = trimLeadingZeros(a)
+	b = trimLeadingZeros(b)
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func trimLeadingZeros(s string) string {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+package encoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapsliceSort(t *testing.T) {
+	items := func(keys ...string) *Mapslice {
+		m := &Mapslice{}
+		for _, key := range keys {
+			m.Items = append(m.Items, MapItem{Key: []byte(key), Value: []byte("0,")})
+		}
+		return m
+	}
+	keys := func(m *Mapslice) []string {
+		var keys []string
+		for _, item := range m.Items {
+			keys = append(keys, string(item.Key))
+		}
+		return keys
+	}
+	tests := []struct {
+		name string
+		less func(a, b string) bool
+		in   []string
+		out  []string
+	}{
+		{
+			name: "default",
+			in:   []string{`"item10",`, `"item2",`, `"item1",`},
+			out:  []string{`"item1",`, `"item10",`, `"item2",`},
+		},
+		{
+			name: "natural",
+			less: NaturalMapKeyLess,
+			in:   []string{`"item10",`, `"item2",`, `"item1",`, `"item02",`, `"b",`, `"a10b",`, `"a9c",`},
+			out:  []string{`"a9c",`, `"a10b",`, `"b",`, `"item1",`, `"item02",`, `"item2",`, `"item10",`},
+		},
+		{
+			name: "numeric",
+			less: NumericMapKeyLess,
+			in:   []string{`"10",`, `"x",`, `"9",`, `"-1.5",`, `"1e1",`},
+			out:  []string{`"-1.5",`, `"9",`, `"10",`, `"1e1",`, `"x",`},
+		},
+		{
+			name: "numeric with non-JSON numbers",
+			less: NumericMapKeyLess,
+			in:   []string{`"NaN",`, `"2",`, `"Inf",`, `"0x10",`, `"1_0",`, `"1e400",`, `"-Inf",`, `"+1",`, `".5",`, `"01",`, `"1",`},
+			out:  []string{`"1",`, `"2",`, `"1e400",`, `"+1",`, `"-Inf",`, `".5",`, `"01",`, `"0x10",`, `"1_0",`, `"Inf",`, `"NaN",`},
+		},
+		{
+			name: "escaped and indented",
+			less: func(a, b string) bool { return a > b },
+			in:   []string{"\"a\\u003cb\": ", "\"a>\": ", "\"a\\\"\": "},
+			out:  []string{"\"a>\": ", "\"a\\u003cb\": ", "\"a\\\"\": "},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &RuntimeContext{Option: &Option{}}
+			if test.less != nil {
+				ctx.Option.Flag |= MapKeyOrderOption
+				ctx.Option.MapKeyLess = test.less
+			}
+			m := items(test.in...)
+			m.Sort(ctx)
+			if got := keys(m); !reflect.DeepEqual(got, test.out) {
+				t.Fatalf("expected %q but got %q", test.out, got)
+			}
+		})
+	}
+}
+
+func TestIsNumberKey(t *testing.T) {
+	for _, s := range []string{"0", "-0", "1", "-12", "1.5", "0.25", "1e5", "1E+5", "-1.5e-10"} {
+		if !isNumberKey(s) {
+			t.Errorf("expected %q to be a number", s)
+		}
+	}
+	for _, s := range []string{"", "-", "+1", "01", ".5", "1.", "1e", "1e+", "0x10", "1_000", "NaN", "Inf", "-Inf", "infinity", " 1", "1 "} {
+		if isNumberKey(s) {
+			t.Errorf("expected %q not to be a number", s)
+		}
+	}
+}
@@ -23,15 +23,22 @@ const (
 	IsNilableTypeFlags     OpFlags = 1 << 7
 	MarshalerContextFlags  OpFlags = 1 << 8
 	NonEmptyInterfaceFlags OpFlags = 1 << 9
+	FloatPrecisionFlags    OpFlags = 1 << 10
+	BigNumberFlags         OpFlags = 1 << 11
+	ByteArrayFlags         OpFlags = 1 << 12
+	NilAsEmptyFlags        OpFlags = 1 << 13
+	BigNumberStringFlags   OpFlags = 1 << 14
 )
 
 type Opcode struct {
 	Op         OpType  // operation type
+	Precision  uint8   // number of digits after the decimal point for float value. used if FloatPrecisionFlags is set
+	BytesEnc   uint8   // runtime.BytesEncoding for []byte or [N]byte value. 0 means the default
 	Idx        uint32  // offset to access ptr
 	Next       *Opcode // next opcode
 	End        *Opcode // array/slice/struct/map end
 	NextField  *Opcode // next struct field
-	Key        string  // struct field key
+	Key        string  // struct field key, or format for time.Time value ( empty means the default )
 	Offset     uint32  // offset size from struct header
 	PtrNum     uint8   // pointer number: e.g. double pointer is 2.
 	NumBitSize uint8
@@ -334,6 +341,8 @@ func copyOpcode(code *Opcode) *Opcode {
 			PtrNum:     c.PtrNum,
 			NumBitSize: c.NumBitSize,
 			Flags:      c.Flags,
+			Precision:  c.Precision,
+			BytesEnc:   c.BytesEnc,
 			Idx:        c.Idx,
 			Offset:     c.Offset,
 			Type:       c.Type,
@@ -598,6 +607,74 @@ func newSliceElemCode(ctx *compileContext, typ *runtime.Type, head *Opcode, size
 	}
 }
 
+func newChanHeaderCode(ctx *compileContext, typ *runtime.Type) *Opcode {
+	idx := opcodeOffset(ctx.ptrIndex)
+	ctx.incPtrIndex()
+	elemIdx := opcodeOffset(ctx.ptrIndex)
+	return &Opcode{
+		Op:         OpChan,
+		Type:       typ,
+		Idx:        idx,
+		DisplayIdx: ctx.opcodeIndex,
+		ElemIdx:    elemIdx,
+		Indent:     ctx.indent,
+	}
+}
+
+func newChanElemCode(ctx *compileContext, typ *runtime.Type, head *Opcode) *Opcode {
+	return &Opcode{
+		Op:         OpChanElem,
+		Type:       typ,
+		Idx:        head.Idx,
+		DisplayIdx: ctx.opcodeIndex,
+		ElemIdx:    head.ElemIdx,
+		Indent:     ctx.indent,
+	}
+}
+
+func newOrderedObjectHeaderCode(ctx *compileContext, typ *runtime.Type) *Opcode {
+	idx := opcodeOffset(ctx.ptrIndex)
+	ctx.incPtrIndex()
+	elemIdx := opcodeOffset(ctx.ptrIndex)
+	ctx.incPtrIndex()
+	length := opcodeOffset(ctx.ptrIndex)
+	return &Opcode{
+		Op:         OpOrderedObject,
+		Type:       typ,
+		Idx:        idx,
+		DisplayIdx: ctx.opcodeIndex,
+		ElemIdx:    elemIdx,
+		Length:     length,
+		Indent:     ctx.indent,
+	}
+}
+
+func newOrderedObjectKeyCode(ctx *compileContext, typ *runtime.Type, head *Opcode) *Opcode {
+	return &Opcode{
+		Op:         OpOrderedObjectKey,
+		Type:       typ,
+		Idx:        head.Idx,
+		DisplayIdx: ctx.opcodeIndex,
+		ElemIdx:    head.ElemIdx,
+		Length:     head.Length,
+		Indent:     ctx.indent,
+		Size:       uint32(typ.Elem().Size()),
+		Offset:     uint32(unsafe.Offsetof(runtime.OrderedObjectEntry{}.Value)),
+	}
+}
+
+func newOrderedObjectElemCode(ctx *compileContext, typ *runtime.Type, head *Opcode) *Opcode {
+	return &Opcode{
+		Op:         OpOrderedObjectElem,
+		Type:       typ,
+		Idx:        head.Idx,
+		DisplayIdx: ctx.opcodeIndex,
+		ElemIdx:    head.ElemIdx,
+		Length:     head.Length,
+		Indent:     ctx.indent,
+	}
+}
+
 func newArrayHeaderCode(ctx *compileContext, typ *runtime.Type, alen int) *Opcode {
 	idx := opcodeOffset(ctx.ptrIndex)
 	ctx.incPtrIndex()
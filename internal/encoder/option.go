@@ -1,8 +1,12 @@
 package encoder
 
-import "context"
+import (
+	"context"
 
-type OptionFlag uint8
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+type OptionFlag uint32
 
 const (
 	HTMLEscapeOption OptionFlag = 1 << iota
@@ -11,12 +15,36 @@ const (
 	DebugOption
 	ColorizeOption
 	ContextOption
+	FloatPrecisionOption
+	FloatExponentNeverOption
+	FloatExponentAlwaysOption
+	NonFiniteFloatNullOption
+	NonFiniteFloatStringOption
+	MapKeyOrderOption
+	TimeFormatOption
+	BytesEncodingOption
+	NilAsEmptyOption
+	EscapeMinimalOption
+	EscapeASCIIOption
+	FlushOption
+	ParallelOption
+)
+
+const (
+	floatFormatOptions    = FloatPrecisionOption | FloatExponentNeverOption | FloatExponentAlwaysOption
+	nonFiniteFloatOptions = NonFiniteFloatNullOption | NonFiniteFloatStringOption
+	stringEscapeOptions   = EscapeMinimalOption | EscapeASCIIOption
 )
 
 type Option struct {
-	Flag        OptionFlag
-	ColorScheme *ColorScheme
-	Context     context.Context
+	Flag           OptionFlag
+	ColorScheme    *ColorScheme
+	Context        context.Context
+	FloatPrecision int
+	MapKeyLess     func(a, b string) bool
+	TimeFormat     string
+	BytesEncoding  runtime.BytesEncoding
+	Parallelism    int
 }
 
 type EncodeFormat struct {
@@ -22,7 +22,7 @@ const (
 	CodeStructEnd   CodeType = 11
 )
 
-var opTypeStrings = [400]string{
+var opTypeStrings = [411]string{
 	"End",
 	"Interface",
 	"Ptr",
@@ -37,6 +37,17 @@ var opTypeStrings = [400]string{
 	"RecursivePtr",
 	"RecursiveEnd",
 	"InterfaceEnd",
+	"OrderedObject",
+	"OrderedObjectPtr",
+	"OrderedObjectKey",
+	"OrderedObjectElem",
+	"OrderedObjectEnd",
+	"Time",
+	"TimePtr",
+	"Chan",
+	"ChanPtr",
+	"ChanElem",
+	"ChanEnd",
 	"Int",
 	"Uint",
 	"Float32",
@@ -442,396 +453,407 @@ const (
 	OpRecursivePtr                           OpType = 11
 	OpRecursiveEnd                           OpType = 12
 	OpInterfaceEnd                           OpType = 13
-	OpInt                                    OpType = 14
-	OpUint                                   OpType = 15
-	OpFloat32                                OpType = 16
-	OpFloat64                                OpType = 17
-	OpBool                                   OpType = 18
-	OpString                                 OpType = 19
-	OpBytes                                  OpType = 20
-	OpNumber                                 OpType = 21
-	OpArray                                  OpType = 22
-	OpMap                                    OpType = 23
-	OpSlice                                  OpType = 24
-	OpStruct                                 OpType = 25
-	OpMarshalJSON                            OpType = 26
-	OpMarshalText                            OpType = 27
-	OpIntString                              OpType = 28
-	OpUintString                             OpType = 29
-	OpFloat32String                          OpType = 30
-	OpFloat64String                          OpType = 31
-	OpBoolString                             OpType = 32
-	OpStringString                           OpType = 33
-	OpNumberString                           OpType = 34
-	OpIntPtr                                 OpType = 35
-	OpUintPtr                                OpType = 36
-	OpFloat32Ptr                             OpType = 37
-	OpFloat64Ptr                             OpType = 38
-	OpBoolPtr                                OpType = 39
-	OpStringPtr                              OpType = 40
-	OpBytesPtr                               OpType = 41
-	OpNumberPtr                              OpType = 42
-	OpArrayPtr                               OpType = 43
-	OpMapPtr                                 OpType = 44
-	OpSlicePtr                               OpType = 45
-	OpMarshalJSONPtr                         OpType = 46
-	OpMarshalTextPtr                         OpType = 47
-	OpInterfacePtr                           OpType = 48
-	OpIntPtrString                           OpType = 49
-	OpUintPtrString                          OpType = 50
-	OpFloat32PtrString                       OpType = 51
-	OpFloat64PtrString                       OpType = 52
-	OpBoolPtrString                          OpType = 53
-	OpStringPtrString                        OpType = 54
-	OpNumberPtrString                        OpType = 55
-	OpStructHeadInt                          OpType = 56
-	OpStructHeadOmitEmptyInt                 OpType = 57
-	OpStructPtrHeadInt                       OpType = 58
-	OpStructPtrHeadOmitEmptyInt              OpType = 59
-	OpStructHeadUint                         OpType = 60
-	OpStructHeadOmitEmptyUint                OpType = 61
-	OpStructPtrHeadUint                      OpType = 62
-	OpStructPtrHeadOmitEmptyUint             OpType = 63
-	OpStructHeadFloat32                      OpType = 64
-	OpStructHeadOmitEmptyFloat32             OpType = 65
-	OpStructPtrHeadFloat32                   OpType = 66
-	OpStructPtrHeadOmitEmptyFloat32          OpType = 67
-	OpStructHeadFloat64                      OpType = 68
-	OpStructHeadOmitEmptyFloat64             OpType = 69
-	OpStructPtrHeadFloat64                   OpType = 70
-	OpStructPtrHeadOmitEmptyFloat64          OpType = 71
-	OpStructHeadBool                         OpType = 72
-	OpStructHeadOmitEmptyBool                OpType = 73
-	OpStructPtrHeadBool                      OpType = 74
-	OpStructPtrHeadOmitEmptyBool             OpType = 75
-	OpStructHeadString                       OpType = 76
-	OpStructHeadOmitEmptyString              OpType = 77
-	OpStructPtrHeadString                    OpType = 78
-	OpStructPtrHeadOmitEmptyString           OpType = 79
-	OpStructHeadBytes                        OpType = 80
-	OpStructHeadOmitEmptyBytes               OpType = 81
-	OpStructPtrHeadBytes                     OpType = 82
-	OpStructPtrHeadOmitEmptyBytes            OpType = 83
-	OpStructHeadNumber                       OpType = 84
-	OpStructHeadOmitEmptyNumber              OpType = 85
-	OpStructPtrHeadNumber                    OpType = 86
-	OpStructPtrHeadOmitEmptyNumber           OpType = 87
-	OpStructHeadArray                        OpType = 88
-	OpStructHeadOmitEmptyArray               OpType = 89
-	OpStructPtrHeadArray                     OpType = 90
-	OpStructPtrHeadOmitEmptyArray            OpType = 91
-	OpStructHeadMap                          OpType = 92
-	OpStructHeadOmitEmptyMap                 OpType = 93
-	OpStructPtrHeadMap                       OpType = 94
-	OpStructPtrHeadOmitEmptyMap              OpType = 95
-	OpStructHeadSlice                        OpType = 96
-	OpStructHeadOmitEmptySlice               OpType = 97
-	OpStructPtrHeadSlice                     OpType = 98
-	OpStructPtrHeadOmitEmptySlice            OpType = 99
-	OpStructHeadStruct                       OpType = 100
-	OpStructHeadOmitEmptyStruct              OpType = 101
-	OpStructPtrHeadStruct                    OpType = 102
-	OpStructPtrHeadOmitEmptyStruct           OpType = 103
-	OpStructHeadMarshalJSON                  OpType = 104
-	OpStructHeadOmitEmptyMarshalJSON         OpType = 105
-	OpStructPtrHeadMarshalJSON               OpType = 106
-	OpStructPtrHeadOmitEmptyMarshalJSON      OpType = 107
-	OpStructHeadMarshalText                  OpType = 108
-	OpStructHeadOmitEmptyMarshalText         OpType = 109
-	OpStructPtrHeadMarshalText               OpType = 110
-	OpStructPtrHeadOmitEmptyMarshalText      OpType = 111
-	OpStructHeadIntString                    OpType = 112
-	OpStructHeadOmitEmptyIntString           OpType = 113
-	OpStructPtrHeadIntString                 OpType = 114
-	OpStructPtrHeadOmitEmptyIntString        OpType = 115
-	OpStructHeadUintString                   OpType = 116
-	OpStructHeadOmitEmptyUintString          OpType = 117
-	OpStructPtrHeadUintString                OpType = 118
-	OpStructPtrHeadOmitEmptyUintString       OpType = 119
-	OpStructHeadFloat32String                OpType = 120
-	OpStructHeadOmitEmptyFloat32String       OpType = 121
-	OpStructPtrHeadFloat32String             OpType = 122
-	OpStructPtrHeadOmitEmptyFloat32String    OpType = 123
-	OpStructHeadFloat64String                OpType = 124
-	OpStructHeadOmitEmptyFloat64String       OpType = 125
-	OpStructPtrHeadFloat64String             OpType = 126
-	OpStructPtrHeadOmitEmptyFloat64String    OpType = 127
-	OpStructHeadBoolString                   OpType = 128
-	OpStructHeadOmitEmptyBoolString          OpType = 129
-	OpStructPtrHeadBoolString                OpType = 130
-	OpStructPtrHeadOmitEmptyBoolString       OpType = 131
-	OpStructHeadStringString                 OpType = 132
-	OpStructHeadOmitEmptyStringString        OpType = 133
-	OpStructPtrHeadStringString              OpType = 134
-	OpStructPtrHeadOmitEmptyStringString     OpType = 135
-	OpStructHeadNumberString                 OpType = 136
-	OpStructHeadOmitEmptyNumberString        OpType = 137
-	OpStructPtrHeadNumberString              OpType = 138
-	OpStructPtrHeadOmitEmptyNumberString     OpType = 139
-	OpStructHeadIntPtr                       OpType = 140
-	OpStructHeadOmitEmptyIntPtr              OpType = 141
-	OpStructPtrHeadIntPtr                    OpType = 142
-	OpStructPtrHeadOmitEmptyIntPtr           OpType = 143
-	OpStructHeadUintPtr                      OpType = 144
-	OpStructHeadOmitEmptyUintPtr             OpType = 145
-	OpStructPtrHeadUintPtr                   OpType = 146
-	OpStructPtrHeadOmitEmptyUintPtr          OpType = 147
-	OpStructHeadFloat32Ptr                   OpType = 148
-	OpStructHeadOmitEmptyFloat32Ptr          OpType = 149
-	OpStructPtrHeadFloat32Ptr                OpType = 150
-	OpStructPtrHeadOmitEmptyFloat32Ptr       OpType = 151
-	OpStructHeadFloat64Ptr                   OpType = 152
-	OpStructHeadOmitEmptyFloat64Ptr          OpType = 153
-	OpStructPtrHeadFloat64Ptr                OpType = 154
-	OpStructPtrHeadOmitEmptyFloat64Ptr       OpType = 155
-	OpStructHeadBoolPtr                      OpType = 156
-	OpStructHeadOmitEmptyBoolPtr             OpType = 157
-	OpStructPtrHeadBoolPtr                   OpType = 158
-	OpStructPtrHeadOmitEmptyBoolPtr          OpType = 159
-	OpStructHeadStringPtr                    OpType = 160
-	OpStructHeadOmitEmptyStringPtr           OpType = 161
-	OpStructPtrHeadStringPtr                 OpType = 162
-	OpStructPtrHeadOmitEmptyStringPtr        OpType = 163
-	OpStructHeadBytesPtr                     OpType = 164
-	OpStructHeadOmitEmptyBytesPtr            OpType = 165
-	OpStructPtrHeadBytesPtr                  OpType = 166
-	OpStructPtrHeadOmitEmptyBytesPtr         OpType = 167
-	OpStructHeadNumberPtr                    OpType = 168
-	OpStructHeadOmitEmptyNumberPtr           OpType = 169
-	OpStructPtrHeadNumberPtr                 OpType = 170
-	OpStructPtrHeadOmitEmptyNumberPtr        OpType = 171
-	OpStructHeadArrayPtr                     OpType = 172
-	OpStructHeadOmitEmptyArrayPtr            OpType = 173
-	OpStructPtrHeadArrayPtr                  OpType = 174
-	OpStructPtrHeadOmitEmptyArrayPtr         OpType = 175
-	OpStructHeadMapPtr                       OpType = 176
-	OpStructHeadOmitEmptyMapPtr              OpType = 177
-	OpStructPtrHeadMapPtr                    OpType = 178
-	OpStructPtrHeadOmitEmptyMapPtr           OpType = 179
-	OpStructHeadSlicePtr                     OpType = 180
-	OpStructHeadOmitEmptySlicePtr            OpType = 181
-	OpStructPtrHeadSlicePtr                  OpType = 182
-	OpStructPtrHeadOmitEmptySlicePtr         OpType = 183
-	OpStructHeadMarshalJSONPtr               OpType = 184
-	OpStructHeadOmitEmptyMarshalJSONPtr      OpType = 185
-	OpStructPtrHeadMarshalJSONPtr            OpType = 186
-	OpStructPtrHeadOmitEmptyMarshalJSONPtr   OpType = 187
-	OpStructHeadMarshalTextPtr               OpType = 188
-	OpStructHeadOmitEmptyMarshalTextPtr      OpType = 189
-	OpStructPtrHeadMarshalTextPtr            OpType = 190
-	OpStructPtrHeadOmitEmptyMarshalTextPtr   OpType = 191
-	OpStructHeadInterfacePtr                 OpType = 192
-	OpStructHeadOmitEmptyInterfacePtr        OpType = 193
-	OpStructPtrHeadInterfacePtr              OpType = 194
-	OpStructPtrHeadOmitEmptyInterfacePtr     OpType = 195
-	OpStructHeadIntPtrString                 OpType = 196
-	OpStructHeadOmitEmptyIntPtrString        OpType = 197
-	OpStructPtrHeadIntPtrString              OpType = 198
-	OpStructPtrHeadOmitEmptyIntPtrString     OpType = 199
-	OpStructHeadUintPtrString                OpType = 200
-	OpStructHeadOmitEmptyUintPtrString       OpType = 201
-	OpStructPtrHeadUintPtrString             OpType = 202
-	OpStructPtrHeadOmitEmptyUintPtrString    OpType = 203
-	OpStructHeadFloat32PtrString             OpType = 204
-	OpStructHeadOmitEmptyFloat32PtrString    OpType = 205
-	OpStructPtrHeadFloat32PtrString          OpType = 206
-	OpStructPtrHeadOmitEmptyFloat32PtrString OpType = 207
-	OpStructHeadFloat64PtrString             OpType = 208
-	OpStructHeadOmitEmptyFloat64PtrString    OpType = 209
-	OpStructPtrHeadFloat64PtrString          OpType = 210
-	OpStructPtrHeadOmitEmptyFloat64PtrString OpType = 211
-	OpStructHeadBoolPtrString                OpType = 212
-	OpStructHeadOmitEmptyBoolPtrString       OpType = 213
-	OpStructPtrHeadBoolPtrString             OpType = 214
-	OpStructPtrHeadOmitEmptyBoolPtrString    OpType = 215
-	OpStructHeadStringPtrString              OpType = 216
-	OpStructHeadOmitEmptyStringPtrString     OpType = 217
-	OpStructPtrHeadStringPtrString           OpType = 218
-	OpStructPtrHeadOmitEmptyStringPtrString  OpType = 219
-	OpStructHeadNumberPtrString              OpType = 220
-	OpStructHeadOmitEmptyNumberPtrString     OpType = 221
-	OpStructPtrHeadNumberPtrString           OpType = 222
-	OpStructPtrHeadOmitEmptyNumberPtrString  OpType = 223
-	OpStructHead                             OpType = 224
-	OpStructHeadOmitEmpty                    OpType = 225
-	OpStructPtrHead                          OpType = 226
-	OpStructPtrHeadOmitEmpty                 OpType = 227
-	OpStructFieldInt                         OpType = 228
-	OpStructFieldOmitEmptyInt                OpType = 229
-	OpStructEndInt                           OpType = 230
-	OpStructEndOmitEmptyInt                  OpType = 231
-	OpStructFieldUint                        OpType = 232
-	OpStructFieldOmitEmptyUint               OpType = 233
-	OpStructEndUint                          OpType = 234
-	OpStructEndOmitEmptyUint                 OpType = 235
-	OpStructFieldFloat32                     OpType = 236
-	OpStructFieldOmitEmptyFloat32            OpType = 237
-	OpStructEndFloat32                       OpType = 238
-	OpStructEndOmitEmptyFloat32              OpType = 239
-	OpStructFieldFloat64                     OpType = 240
-	OpStructFieldOmitEmptyFloat64            OpType = 241
-	OpStructEndFloat64                       OpType = 242
-	OpStructEndOmitEmptyFloat64              OpType = 243
-	OpStructFieldBool                        OpType = 244
-	OpStructFieldOmitEmptyBool               OpType = 245
-	OpStructEndBool                          OpType = 246
-	OpStructEndOmitEmptyBool                 OpType = 247
-	OpStructFieldString                      OpType = 248
-	OpStructFieldOmitEmptyString             OpType = 249
-	OpStructEndString                        OpType = 250
-	OpStructEndOmitEmptyString               OpType = 251
-	OpStructFieldBytes                       OpType = 252
-	OpStructFieldOmitEmptyBytes              OpType = 253
-	OpStructEndBytes                         OpType = 254
-	OpStructEndOmitEmptyBytes                OpType = 255
-	OpStructFieldNumber                      OpType = 256
-	OpStructFieldOmitEmptyNumber             OpType = 257
-	OpStructEndNumber                        OpType = 258
-	OpStructEndOmitEmptyNumber               OpType = 259
-	OpStructFieldArray                       OpType = 260
-	OpStructFieldOmitEmptyArray              OpType = 261
-	OpStructEndArray                         OpType = 262
-	OpStructEndOmitEmptyArray                OpType = 263
-	OpStructFieldMap                         OpType = 264
-	OpStructFieldOmitEmptyMap                OpType = 265
-	OpStructEndMap                           OpType = 266
-	OpStructEndOmitEmptyMap                  OpType = 267
-	OpStructFieldSlice                       OpType = 268
-	OpStructFieldOmitEmptySlice              OpType = 269
-	OpStructEndSlice                         OpType = 270
-	OpStructEndOmitEmptySlice                OpType = 271
-	OpStructFieldStruct                      OpType = 272
-	OpStructFieldOmitEmptyStruct             OpType = 273
-	OpStructEndStruct                        OpType = 274
-	OpStructEndOmitEmptyStruct               OpType = 275
-	OpStructFieldMarshalJSON                 OpType = 276
-	OpStructFieldOmitEmptyMarshalJSON        OpType = 277
-	OpStructEndMarshalJSON                   OpType = 278
-	OpStructEndOmitEmptyMarshalJSON          OpType = 279
-	OpStructFieldMarshalText                 OpType = 280
-	OpStructFieldOmitEmptyMarshalText        OpType = 281
-	OpStructEndMarshalText                   OpType = 282
-	OpStructEndOmitEmptyMarshalText          OpType = 283
-	OpStructFieldIntString                   OpType = 284
-	OpStructFieldOmitEmptyIntString          OpType = 285
-	OpStructEndIntString                     OpType = 286
-	OpStructEndOmitEmptyIntString            OpType = 287
-	OpStructFieldUintString                  OpType = 288
-	OpStructFieldOmitEmptyUintString         OpType = 289
-	OpStructEndUintString                    OpType = 290
-	OpStructEndOmitEmptyUintString           OpType = 291
-	OpStructFieldFloat32String               OpType = 292
-	OpStructFieldOmitEmptyFloat32String      OpType = 293
-	OpStructEndFloat32String                 OpType = 294
-	OpStructEndOmitEmptyFloat32String        OpType = 295
-	OpStructFieldFloat64String               OpType = 296
-	OpStructFieldOmitEmptyFloat64String      OpType = 297
-	OpStructEndFloat64String                 OpType = 298
-	OpStructEndOmitEmptyFloat64String        OpType = 299
-	OpStructFieldBoolString                  OpType = 300
-	OpStructFieldOmitEmptyBoolString         OpType = 301
-	OpStructEndBoolString                    OpType = 302
-	OpStructEndOmitEmptyBoolString           OpType = 303
-	OpStructFieldStringString                OpType = 304
-	OpStructFieldOmitEmptyStringString       OpType = 305
-	OpStructEndStringString                  OpType = 306
-	OpStructEndOmitEmptyStringString         OpType = 307
-	OpStructFieldNumberString                OpType = 308
-	OpStructFieldOmitEmptyNumberString       OpType = 309
-	OpStructEndNumberString                  OpType = 310
-	OpStructEndOmitEmptyNumberString         OpType = 311
-	OpStructFieldIntPtr                      OpType = 312
-	OpStructFieldOmitEmptyIntPtr             OpType = 313
-	OpStructEndIntPtr                        OpType = 314
-	OpStructEndOmitEmptyIntPtr               OpType = 315
-	OpStructFieldUintPtr                     OpType = 316
-	OpStructFieldOmitEmptyUintPtr            OpType = 317
-	OpStructEndUintPtr                       OpType = 318
-	OpStructEndOmitEmptyUintPtr              OpType = 319
-	OpStructFieldFloat32Ptr                  OpType = 320
-	OpStructFieldOmitEmptyFloat32Ptr         OpType = 321
-	OpStructEndFloat32Ptr                    OpType = 322
-	OpStructEndOmitEmptyFloat32Ptr           OpType = 323
-	OpStructFieldFloat64Ptr                  OpType = 324
-	OpStructFieldOmitEmptyFloat64Ptr         OpType = 325
-	OpStructEndFloat64Ptr                    OpType = 326
-	OpStructEndOmitEmptyFloat64Ptr           OpType = 327
-	OpStructFieldBoolPtr                     OpType = 328
-	OpStructFieldOmitEmptyBoolPtr            OpType = 329
-	OpStructEndBoolPtr                       OpType = 330
-	OpStructEndOmitEmptyBoolPtr              OpType = 331
-	OpStructFieldStringPtr                   OpType = 332
-	OpStructFieldOmitEmptyStringPtr          OpType = 333
-	OpStructEndStringPtr                     OpType = 334
-	OpStructEndOmitEmptyStringPtr            OpType = 335
-	OpStructFieldBytesPtr                    OpType = 336
-	OpStructFieldOmitEmptyBytesPtr           OpType = 337
-	OpStructEndBytesPtr                      OpType = 338
-	OpStructEndOmitEmptyBytesPtr             OpType = 339
-	OpStructFieldNumberPtr                   OpType = 340
-	OpStructFieldOmitEmptyNumberPtr          OpType = 341
-	OpStructEndNumberPtr                     OpType = 342
-	OpStructEndOmitEmptyNumberPtr            OpType = 343
-	OpStructFieldArrayPtr                    OpType = 344
-	OpStructFieldOmitEmptyArrayPtr           OpType = 345
-	OpStructEndArrayPtr                      OpType = 346
-	OpStructEndOmitEmptyArrayPtr             OpType = 347
-	OpStructFieldMapPtr                      OpType = 348
-	OpStructFieldOmitEmptyMapPtr             OpType = 349
-	OpStructEndMapPtr                        OpType = 350
-	OpStructEndOmitEmptyMapPtr               OpType = 351
-	OpStructFieldSlicePtr                    OpType = 352
-	OpStructFieldOmitEmptySlicePtr           OpType = 353
-	OpStructEndSlicePtr                      OpType = 354
-	OpStructEndOmitEmptySlicePtr             OpType = 355
-	OpStructFieldMarshalJSONPtr              OpType = 356
-	OpStructFieldOmitEmptyMarshalJSONPtr     OpType = 357
-	OpStructEndMarshalJSONPtr                OpType = 358
-	OpStructEndOmitEmptyMarshalJSONPtr       OpType = 359
-	OpStructFieldMarshalTextPtr              OpType = 360
-	OpStructFieldOmitEmptyMarshalTextPtr     OpType = 361
-	OpStructEndMarshalTextPtr                OpType = 362
-	OpStructEndOmitEmptyMarshalTextPtr       OpType = 363
-	OpStructFieldInterfacePtr                OpType = 364
-	OpStructFieldOmitEmptyInterfacePtr       OpType = 365
-	OpStructEndInterfacePtr                  OpType = 366
-	OpStructEndOmitEmptyInterfacePtr         OpType = 367
-	OpStructFieldIntPtrString                OpType = 368
-	OpStructFieldOmitEmptyIntPtrString       OpType = 369
-	OpStructEndIntPtrString                  OpType = 370
-	OpStructEndOmitEmptyIntPtrString         OpType = 371
-	OpStructFieldUintPtrString               OpType = 372
-	OpStructFieldOmitEmptyUintPtrString      OpType = 373
-	OpStructEndUintPtrString                 OpType = 374
-	OpStructEndOmitEmptyUintPtrString        OpType = 375
-	OpStructFieldFloat32PtrString            OpType = 376
-	OpStructFieldOmitEmptyFloat32PtrString   OpType = 377
-	OpStructEndFloat32PtrString              OpType = 378
-	OpStructEndOmitEmptyFloat32PtrString     OpType = 379
-	OpStructFieldFloat64PtrString            OpType = 380
-	OpStructFieldOmitEmptyFloat64PtrString   OpType = 381
-	OpStructEndFloat64PtrString              OpType = 382
-	OpStructEndOmitEmptyFloat64PtrString     OpType = 383
-	OpStructFieldBoolPtrString               OpType = 384
-	OpStructFieldOmitEmptyBoolPtrString      OpType = 385
-	OpStructEndBoolPtrString                 OpType = 386
-	OpStructEndOmitEmptyBoolPtrString        OpType = 387
-	OpStructFieldStringPtrString             OpType = 388
-	OpStructFieldOmitEmptyStringPtrString    OpType = 389
-	OpStructEndStringPtrString               OpType = 390
-	OpStructEndOmitEmptyStringPtrString      OpType = 391
-	OpStructFieldNumberPtrString             OpType = 392
-	OpStructFieldOmitEmptyNumberPtrString    OpType = 393
-	OpStructEndNumberPtrString               OpType = 394
-	OpStructEndOmitEmptyNumberPtrString      OpType = 395
-	OpStructField                            OpType = 396
-	OpStructFieldOmitEmpty                   OpType = 397
-	OpStructEnd                              OpType = 398
-	OpStructEndOmitEmpty                     OpType = 399
+	OpOrderedObject                          OpType = 14
+	OpOrderedObjectPtr                       OpType = 15
+	OpOrderedObjectKey                       OpType = 16
+	OpOrderedObjectElem                      OpType = 17
+	OpOrderedObjectEnd                       OpType = 18
+	OpTime                                   OpType = 19
+	OpTimePtr                                OpType = 20
+	OpChan                                   OpType = 21
+	OpChanPtr                                OpType = 22
+	OpChanElem                               OpType = 23
+	OpChanEnd                                OpType = 24
+	OpInt                                    OpType = 25
+	OpUint                                   OpType = 26
+	OpFloat32                                OpType = 27
+	OpFloat64                                OpType = 28
+	OpBool                                   OpType = 29
+	OpString                                 OpType = 30
+	OpBytes                                  OpType = 31
+	OpNumber                                 OpType = 32
+	OpArray                                  OpType = 33
+	OpMap                                    OpType = 34
+	OpSlice                                  OpType = 35
+	OpStruct                                 OpType = 36
+	OpMarshalJSON                            OpType = 37
+	OpMarshalText                            OpType = 38
+	OpIntString                              OpType = 39
+	OpUintString                             OpType = 40
+	OpFloat32String                          OpType = 41
+	OpFloat64String                          OpType = 42
+	OpBoolString                             OpType = 43
+	OpStringString                           OpType = 44
+	OpNumberString                           OpType = 45
+	OpIntPtr                                 OpType = 46
+	OpUintPtr                                OpType = 47
+	OpFloat32Ptr                             OpType = 48
+	OpFloat64Ptr                             OpType = 49
+	OpBoolPtr                                OpType = 50
+	OpStringPtr                              OpType = 51
+	OpBytesPtr                               OpType = 52
+	OpNumberPtr                              OpType = 53
+	OpArrayPtr                               OpType = 54
+	OpMapPtr                                 OpType = 55
+	OpSlicePtr                               OpType = 56
+	OpMarshalJSONPtr                         OpType = 57
+	OpMarshalTextPtr                         OpType = 58
+	OpInterfacePtr                           OpType = 59
+	OpIntPtrString                           OpType = 60
+	OpUintPtrString                          OpType = 61
+	OpFloat32PtrString                       OpType = 62
+	OpFloat64PtrString                       OpType = 63
+	OpBoolPtrString                          OpType = 64
+	OpStringPtrString                        OpType = 65
+	OpNumberPtrString                        OpType = 66
+	OpStructHeadInt                          OpType = 67
+	OpStructHeadOmitEmptyInt                 OpType = 68
+	OpStructPtrHeadInt                       OpType = 69
+	OpStructPtrHeadOmitEmptyInt              OpType = 70
+	OpStructHeadUint                         OpType = 71
+	OpStructHeadOmitEmptyUint                OpType = 72
+	OpStructPtrHeadUint                      OpType = 73
+	OpStructPtrHeadOmitEmptyUint             OpType = 74
+	OpStructHeadFloat32                      OpType = 75
+	OpStructHeadOmitEmptyFloat32             OpType = 76
+	OpStructPtrHeadFloat32                   OpType = 77
+	OpStructPtrHeadOmitEmptyFloat32          OpType = 78
+	OpStructHeadFloat64                      OpType = 79
+	OpStructHeadOmitEmptyFloat64             OpType = 80
+	OpStructPtrHeadFloat64                   OpType = 81
+	OpStructPtrHeadOmitEmptyFloat64          OpType = 82
+	OpStructHeadBool                         OpType = 83
+	OpStructHeadOmitEmptyBool                OpType = 84
+	OpStructPtrHeadBool                      OpType = 85
+	OpStructPtrHeadOmitEmptyBool             OpType = 86
+	OpStructHeadString                       OpType = 87
+	OpStructHeadOmitEmptyString              OpType = 88
+	OpStructPtrHeadString                    OpType = 89
+	OpStructPtrHeadOmitEmptyString           OpType = 90
+	OpStructHeadBytes                        OpType = 91
+	OpStructHeadOmitEmptyBytes               OpType = 92
+	OpStructPtrHeadBytes                     OpType = 93
+	OpStructPtrHeadOmitEmptyBytes            OpType = 94
+	OpStructHeadNumber                       OpType = 95
+	OpStructHeadOmitEmptyNumber              OpType = 96
+	OpStructPtrHeadNumber                    OpType = 97
+	OpStructPtrHeadOmitEmptyNumber           OpType = 98
+	OpStructHeadArray                        OpType = 99
+	OpStructHeadOmitEmptyArray               OpType = 100
+	OpStructPtrHeadArray                     OpType = 101
+	OpStructPtrHeadOmitEmptyArray            OpType = 102
+	OpStructHeadMap                          OpType = 103
+	OpStructHeadOmitEmptyMap                 OpType = 104
+	OpStructPtrHeadMap                       OpType = 105
+	OpStructPtrHeadOmitEmptyMap              OpType = 106
+	OpStructHeadSlice                        OpType = 107
+	OpStructHeadOmitEmptySlice               OpType = 108
+	OpStructPtrHeadSlice                     OpType = 109
+	OpStructPtrHeadOmitEmptySlice            OpType = 110
+	OpStructHeadStruct                       OpType = 111
+	OpStructHeadOmitEmptyStruct              OpType = 112
+	OpStructPtrHeadStruct                    OpType = 113
+	OpStructPtrHeadOmitEmptyStruct           OpType = 114
+	OpStructHeadMarshalJSON                  OpType = 115
+	OpStructHeadOmitEmptyMarshalJSON         OpType = 116
+	OpStructPtrHeadMarshalJSON               OpType = 117
+	OpStructPtrHeadOmitEmptyMarshalJSON      OpType = 118
+	OpStructHeadMarshalText                  OpType = 119
+	OpStructHeadOmitEmptyMarshalText         OpType = 120
+	OpStructPtrHeadMarshalText               OpType = 121
+	OpStructPtrHeadOmitEmptyMarshalText      OpType = 122
+	OpStructHeadIntString                    OpType = 123
+	OpStructHeadOmitEmptyIntString           OpType = 124
+	OpStructPtrHeadIntString                 OpType = 125
+	OpStructPtrHeadOmitEmptyIntString        OpType = 126
+	OpStructHeadUintString                   OpType = 127
+	OpStructHeadOmitEmptyUintString          OpType = 128
+	OpStructPtrHeadUintString                OpType = 129
+	OpStructPtrHeadOmitEmptyUintString       OpType = 130
+	OpStructHeadFloat32String                OpType = 131
+	OpStructHeadOmitEmptyFloat32String       OpType = 132
+	OpStructPtrHeadFloat32String             OpType = 133
+	OpStructPtrHeadOmitEmptyFloat32String    OpType = 134
+	OpStructHeadFloat64String                OpType = 135
+	OpStructHeadOmitEmptyFloat64String       OpType = 136
+	OpStructPtrHeadFloat64String             OpType = 137
+	OpStructPtrHeadOmitEmptyFloat64String    OpType = 138
+	OpStructHeadBoolString                   OpType = 139
+	OpStructHeadOmitEmptyBoolString          OpType = 140
+	OpStructPtrHeadBoolString                OpType = 141
+	OpStructPtrHeadOmitEmptyBoolString       OpType = 142
+	OpStructHeadStringString                 OpType = 143
+	OpStructHeadOmitEmptyStringString        OpType = 144
+	OpStructPtrHeadStringString              OpType = 145
+	OpStructPtrHeadOmitEmptyStringString     OpType = 146
+	OpStructHeadNumberString                 OpType = 147
+	OpStructHeadOmitEmptyNumberString        OpType = 148
+	OpStructPtrHeadNumberString              OpType = 149
+	OpStructPtrHeadOmitEmptyNumberString     OpType = 150
+	OpStructHeadIntPtr                       OpType = 151
+	OpStructHeadOmitEmptyIntPtr              OpType = 152
+	OpStructPtrHeadIntPtr                    OpType = 153
+	OpStructPtrHeadOmitEmptyIntPtr           OpType = 154
+	OpStructHeadUintPtr                      OpType = 155
+	OpStructHeadOmitEmptyUintPtr             OpType = 156
+	OpStructPtrHeadUintPtr                   OpType = 157
+	OpStructPtrHeadOmitEmptyUintPtr          OpType = 158
+	OpStructHeadFloat32Ptr                   OpType = 159
+	OpStructHeadOmitEmptyFloat32Ptr          OpType = 160
+	OpStructPtrHeadFloat32Ptr                OpType = 161
+	OpStructPtrHeadOmitEmptyFloat32Ptr       OpType = 162
+	OpStructHeadFloat64Ptr                   OpType = 163
+	OpStructHeadOmitEmptyFloat64Ptr          OpType = 164
+	OpStructPtrHeadFloat64Ptr                OpType = 165
+	OpStructPtrHeadOmitEmptyFloat64Ptr       OpType = 166
+	OpStructHeadBoolPtr                      OpType = 167
+	OpStructHeadOmitEmptyBoolPtr             OpType = 168
+	OpStructPtrHeadBoolPtr                   OpType = 169
+	OpStructPtrHeadOmitEmptyBoolPtr          OpType = 170
+	OpStructHeadStringPtr                    OpType = 171
+	OpStructHeadOmitEmptyStringPtr           OpType = 172
+	OpStructPtrHeadStringPtr                 OpType = 173
+	OpStructPtrHeadOmitEmptyStringPtr        OpType = 174
+	OpStructHeadBytesPtr                     OpType = 175
+	OpStructHeadOmitEmptyBytesPtr            OpType = 176
+	OpStructPtrHeadBytesPtr                  OpType = 177
+	OpStructPtrHeadOmitEmptyBytesPtr         OpType = 178
+	OpStructHeadNumberPtr                    OpType = 179
+	OpStructHeadOmitEmptyNumberPtr           OpType = 180
+	OpStructPtrHeadNumberPtr                 OpType = 181
+	OpStructPtrHeadOmitEmptyNumberPtr        OpType = 182
+	OpStructHeadArrayPtr                     OpType = 183
+	OpStructHeadOmitEmptyArrayPtr            OpType = 184
+	OpStructPtrHeadArrayPtr                  OpType = 185
+	OpStructPtrHeadOmitEmptyArrayPtr         OpType = 186
+	OpStructHeadMapPtr                       OpType = 187
+	OpStructHeadOmitEmptyMapPtr              OpType = 188
+	OpStructPtrHeadMapPtr                    OpType = 189
+	OpStructPtrHeadOmitEmptyMapPtr           OpType = 190
+	OpStructHeadSlicePtr                     OpType = 191
+	OpStructHeadOmitEmptySlicePtr            OpType = 192
+	OpStructPtrHeadSlicePtr                  OpType = 193
+	OpStructPtrHeadOmitEmptySlicePtr         OpType = 194
+	OpStructHeadMarshalJSONPtr               OpType = 195
+	OpStructHeadOmitEmptyMarshalJSONPtr      OpType = 196
+	OpStructPtrHeadMarshalJSONPtr            OpType = 197
+	OpStructPtrHeadOmitEmptyMarshalJSONPtr   OpType = 198
+	OpStructHeadMarshalTextPtr               OpType = 199
+	OpStructHeadOmitEmptyMarshalTextPtr      OpType = 200
+	OpStructPtrHeadMarshalTextPtr            OpType = 201
+	OpStructPtrHeadOmitEmptyMarshalTextPtr   OpType = 202
+	OpStructHeadInterfacePtr                 OpType = 203
+	OpStructHeadOmitEmptyInterfacePtr        OpType = 204
+	OpStructPtrHeadInterfacePtr              OpType = 205
+	OpStructPtrHeadOmitEmptyInterfacePtr     OpType = 206
+	OpStructHeadIntPtrString                 OpType = 207
+	OpStructHeadOmitEmptyIntPtrString        OpType = 208
+	OpStructPtrHeadIntPtrString              OpType = 209
+	OpStructPtrHeadOmitEmptyIntPtrString     OpType = 210
+	OpStructHeadUintPtrString                OpType = 211
+	OpStructHeadOmitEmptyUintPtrString       OpType = 212
+	OpStructPtrHeadUintPtrString             OpType = 213
+	OpStructPtrHeadOmitEmptyUintPtrString    OpType = 214
+	OpStructHeadFloat32PtrString             OpType = 215
+	OpStructHeadOmitEmptyFloat32PtrString    OpType = 216
+	OpStructPtrHeadFloat32PtrString          OpType = 217
+	OpStructPtrHeadOmitEmptyFloat32PtrString OpType = 218
+	OpStructHeadFloat64PtrString             OpType = 219
+	OpStructHeadOmitEmptyFloat64PtrString    OpType = 220
+	OpStructPtrHeadFloat64PtrString          OpType = 221
+	OpStructPtrHeadOmitEmptyFloat64PtrString OpType = 222
+	OpStructHeadBoolPtrString                OpType = 223
+	OpStructHeadOmitEmptyBoolPtrString       OpType = 224
+	OpStructPtrHeadBoolPtrString             OpType = 225
+	OpStructPtrHeadOmitEmptyBoolPtrString    OpType = 226
+	OpStructHeadStringPtrString              OpType = 227
+	OpStructHeadOmitEmptyStringPtrString     OpType = 228
+	OpStructPtrHeadStringPtrString           OpType = 229
+	OpStructPtrHeadOmitEmptyStringPtrString  OpType = 230
+	OpStructHeadNumberPtrString              OpType = 231
+	OpStructHeadOmitEmptyNumberPtrString     OpType = 232
+	OpStructPtrHeadNumberPtrString           OpType = 233
+	OpStructPtrHeadOmitEmptyNumberPtrString  OpType = 234
+	OpStructHead                             OpType = 235
+	OpStructHeadOmitEmpty                    OpType = 236
+	OpStructPtrHead                          OpType = 237
+	OpStructPtrHeadOmitEmpty                 OpType = 238
+	OpStructFieldInt                         OpType = 239
+	OpStructFieldOmitEmptyInt                OpType = 240
+	OpStructEndInt                           OpType = 241
+	OpStructEndOmitEmptyInt                  OpType = 242
+	OpStructFieldUint                        OpType = 243
+	OpStructFieldOmitEmptyUint               OpType = 244
+	OpStructEndUint                          OpType = 245
+	OpStructEndOmitEmptyUint                 OpType = 246
+	OpStructFieldFloat32                     OpType = 247
+	OpStructFieldOmitEmptyFloat32            OpType = 248
+	OpStructEndFloat32                       OpType = 249
+	OpStructEndOmitEmptyFloat32              OpType = 250
+	OpStructFieldFloat64                     OpType = 251
+	OpStructFieldOmitEmptyFloat64            OpType = 252
+	OpStructEndFloat64                       OpType = 253
+	OpStructEndOmitEmptyFloat64              OpType = 254
+	OpStructFieldBool                        OpType = 255
+	OpStructFieldOmitEmptyBool               OpType = 256
+	OpStructEndBool                          OpType = 257
+	OpStructEndOmitEmptyBool                 OpType = 258
+	OpStructFieldString                      OpType = 259
+	OpStructFieldOmitEmptyString             OpType = 260
+	OpStructEndString                        OpType = 261
+	OpStructEndOmitEmptyString               OpType = 262
+	OpStructFieldBytes                       OpType = 263
+	OpStructFieldOmitEmptyBytes              OpType = 264
+	OpStructEndBytes                         OpType = 265
+	OpStructEndOmitEmptyBytes                OpType = 266
+	OpStructFieldNumber                      OpType = 267
+	OpStructFieldOmitEmptyNumber             OpType = 268
+	OpStructEndNumber                        OpType = 269
+	OpStructEndOmitEmptyNumber               OpType = 270
+	OpStructFieldArray                       OpType = 271
+	OpStructFieldOmitEmptyArray              OpType = 272
+	OpStructEndArray                         OpType = 273
+	OpStructEndOmitEmptyArray                OpType = 274
+	OpStructFieldMap                         OpType = 275
+	OpStructFieldOmitEmptyMap                OpType = 276
+	OpStructEndMap                           OpType = 277
+	OpStructEndOmitEmptyMap                  OpType = 278
+	OpStructFieldSlice                       OpType = 279
+	OpStructFieldOmitEmptySlice              OpType = 280
+	OpStructEndSlice                         OpType = 281
+	OpStructEndOmitEmptySlice                OpType = 282
+	OpStructFieldStruct                      OpType = 283
+	OpStructFieldOmitEmptyStruct             OpType = 284
+	OpStructEndStruct                        OpType = 285
+	OpStructEndOmitEmptyStruct               OpType = 286
+	OpStructFieldMarshalJSON                 OpType = 287
+	OpStructFieldOmitEmptyMarshalJSON        OpType = 288
+	OpStructEndMarshalJSON                   OpType = 289
+	OpStructEndOmitEmptyMarshalJSON          OpType = 290
+	OpStructFieldMarshalText                 OpType = 291
+	OpStructFieldOmitEmptyMarshalText        OpType = 292
+	OpStructEndMarshalText                   OpType = 293
+	OpStructEndOmitEmptyMarshalText          OpType = 294
+	OpStructFieldIntString                   OpType = 295
+	OpStructFieldOmitEmptyIntString          OpType = 296
+	OpStructEndIntString                     OpType = 297
+	OpStructEndOmitEmptyIntString            OpType = 298
+	OpStructFieldUintString                  OpType = 299
+	OpStructFieldOmitEmptyUintString         OpType = 300
+	OpStructEndUintString                    OpType = 301
+	OpStructEndOmitEmptyUintString           OpType = 302
+	OpStructFieldFloat32String               OpType = 303
+	OpStructFieldOmitEmptyFloat32String      OpType = 304
+	OpStructEndFloat32String                 OpType = 305
+	OpStructEndOmitEmptyFloat32String        OpType = 306
+	OpStructFieldFloat64String               OpType = 307
+	OpStructFieldOmitEmptyFloat64String      OpType = 308
+	OpStructEndFloat64String                 OpType = 309
+	OpStructEndOmitEmptyFloat64String        OpType = 310
+	OpStructFieldBoolString                  OpType = 311
+	OpStructFieldOmitEmptyBoolString         OpType = 312
+	OpStructEndBoolString                    OpType = 313
+	OpStructEndOmitEmptyBoolString           OpType = 314
+	OpStructFieldStringString                OpType = 315
+	OpStructFieldOmitEmptyStringString       OpType = 316
+	OpStructEndStringString                  OpType = 317
+	OpStructEndOmitEmptyStringString         OpType = 318
+	OpStructFieldNumberString                OpType = 319
+	OpStructFieldOmitEmptyNumberString       OpType = 320
+	OpStructEndNumberString                  OpType = 321
+	OpStructEndOmitEmptyNumberString         OpType = 322
+	OpStructFieldIntPtr                      OpType = 323
+	OpStructFieldOmitEmptyIntPtr             OpType = 324
+	OpStructEndIntPtr                        OpType = 325
+	OpStructEndOmitEmptyIntPtr               OpType = 326
+	OpStructFieldUintPtr                     OpType = 327
+	OpStructFieldOmitEmptyUintPtr            OpType = 328
+	OpStructEndUintPtr                       OpType = 329
+	OpStructEndOmitEmptyUintPtr              OpType = 330
+	OpStructFieldFloat32Ptr                  OpType = 331
+	OpStructFieldOmitEmptyFloat32Ptr         OpType = 332
+	OpStructEndFloat32Ptr                    OpType = 333
+	OpStructEndOmitEmptyFloat32Ptr           OpType = 334
+	OpStructFieldFloat64Ptr                  OpType = 335
+	OpStructFieldOmitEmptyFloat64Ptr         OpType = 336
+	OpStructEndFloat64Ptr                    OpType = 337
+	OpStructEndOmitEmptyFloat64Ptr           OpType = 338
+	OpStructFieldBoolPtr                     OpType = 339
+	OpStructFieldOmitEmptyBoolPtr            OpType = 340
+	OpStructEndBoolPtr                       OpType = 341
+	OpStructEndOmitEmptyBoolPtr              OpType = 342
+	OpStructFieldStringPtr                   OpType = 343
+	OpStructFieldOmitEmptyStringPtr          OpType = 344
+	OpStructEndStringPtr                     OpType = 345
+	OpStructEndOmitEmptyStringPtr            OpType = 346
+	OpStructFieldBytesPtr                    OpType = 347
+	OpStructFieldOmitEmptyBytesPtr           OpType = 348
+	OpStructEndBytesPtr                      OpType = 349
+	OpStructEndOmitEmptyBytesPtr             OpType = 350
+	OpStructFieldNumberPtr                   OpType = 351
+	OpStructFieldOmitEmptyNumberPtr          OpType = 352
+	OpStructEndNumberPtr                     OpType = 353
+	OpStructEndOmitEmptyNumberPtr            OpType = 354
+	OpStructFieldArrayPtr                    OpType = 355
+	OpStructFieldOmitEmptyArrayPtr           OpType = 356
+	OpStructEndArrayPtr                      OpType = 357
+	OpStructEndOmitEmptyArrayPtr             OpType = 358
+	OpStructFieldMapPtr                      OpType = 359
+	OpStructFieldOmitEmptyMapPtr             OpType = 360
+	OpStructEndMapPtr                        OpType = 361
+	OpStructEndOmitEmptyMapPtr               OpType = 362
+	OpStructFieldSlicePtr                    OpType = 363
+	OpStructFieldOmitEmptySlicePtr           OpType = 364
+	OpStructEndSlicePtr                      OpType = 365
+	OpStructEndOmitEmptySlicePtr             OpType = 366
+	OpStructFieldMarshalJSONPtr              OpType = 367
+	OpStructFieldOmitEmptyMarshalJSONPtr     OpType = 368
+	OpStructEndMarshalJSONPtr                OpType = 369
+	OpStructEndOmitEmptyMarshalJSONPtr       OpType = 370
+	OpStructFieldMarshalTextPtr              OpType = 371
+	OpStructFieldOmitEmptyMarshalTextPtr     OpType = 372
+	OpStructEndMarshalTextPtr                OpType = 373
+	OpStructEndOmitEmptyMarshalTextPtr       OpType = 374
+	OpStructFieldInterfacePtr                OpType = 375
+	OpStructFieldOmitEmptyInterfacePtr       OpType = 376
+	OpStructEndInterfacePtr                  OpType = 377
+	OpStructEndOmitEmptyInterfacePtr         OpType = 378
+	OpStructFieldIntPtrString                OpType = 379
+	OpStructFieldOmitEmptyIntPtrString       OpType = 380
+	OpStructEndIntPtrString                  OpType = 381
+	OpStructEndOmitEmptyIntPtrString         OpType = 382
+	OpStructFieldUintPtrString               OpType = 383
+	OpStructFieldOmitEmptyUintPtrString      OpType = 384
+	OpStructEndUintPtrString                 OpType = 385
+	OpStructEndOmitEmptyUintPtrString        OpType = 386
+	OpStructFieldFloat32PtrString            OpType = 387
+	OpStructFieldOmitEmptyFloat32PtrString   OpType = 388
+	OpStructEndFloat32PtrString              OpType = 389
+	OpStructEndOmitEmptyFloat32PtrString     OpType = 390
+	OpStructFieldFloat64PtrString            OpType = 391
+	OpStructFieldOmitEmptyFloat64PtrString   OpType = 392
+	OpStructEndFloat64PtrString              OpType = 393
+	OpStructEndOmitEmptyFloat64PtrString     OpType = 394
+	OpStructFieldBoolPtrString               OpType = 395
+	OpStructFieldOmitEmptyBoolPtrString      OpType = 396
+	OpStructEndBoolPtrString                 OpType = 397
+	OpStructEndOmitEmptyBoolPtrString        OpType = 398
+	OpStructFieldStringPtrString             OpType = 399
+	OpStructFieldOmitEmptyStringPtrString    OpType = 400
+	OpStructEndStringPtrString               OpType = 401
+	OpStructEndOmitEmptyStringPtrString      OpType = 402
+	OpStructFieldNumberPtrString             OpType = 403
+	OpStructFieldOmitEmptyNumberPtrString    OpType = 404
+	OpStructEndNumberPtrString               OpType = 405
+	OpStructEndOmitEmptyNumberPtrString      OpType = 406
+	OpStructField                            OpType = 407
+	OpStructFieldOmitEmpty                   OpType = 408
+	OpStructEnd                              OpType = 409
+	OpStructEndOmitEmpty                     OpType = 410
 )
 
 func (t OpType) String() string {
-	if int(t) >= 400 {
+	if int(t) >= 411 {
 		return ""
 	}
 	return opTypeStrings[int(t)]
@@ -853,6 +875,14 @@ func (t OpType) CodeType() CodeType {
 		return CodeSliceHead
 	case OpSliceElem:
 		return CodeSliceElem
+	case OpOrderedObject, OpOrderedObjectPtr:
+		return CodeSliceHead
+	case OpOrderedObjectElem:
+		return CodeSliceElem
+	case OpChan, OpChanPtr:
+		return CodeSliceHead
+	case OpChanElem:
+		return CodeSliceElem
 	case OpMap, OpMapPtr:
 		return CodeMapHead
 	case OpMapKey:
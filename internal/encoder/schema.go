@@ -0,0 +1,327 @@
+package encoder
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// SchemaDraft is the JSON Schema dialect of the schemas built by Schema.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaBuilder builds the JSON Schema from the codes compiled for the encoder,
+// so that the properties are exactly the fields written by the encoder.
+// The named struct types are put in $defs and referred by $ref, which also covers the recursive types.
+type schemaBuilder struct {
+	defs  runtime.OrderedObject
+	names map[*runtime.Type]string
+	used  map[string]struct{}
+}
+
+// Schema returns the JSON Schema of the values of typ encoded by the default options.
+func Schema(typ *runtime.Type) (runtime.OrderedObject, error) {
+	code, err := newCompiler().typeToCode(typ)
+	if err != nil {
+		return nil, err
+	}
+	b := &schemaBuilder{
+		names: map[*runtime.Type]string{},
+		used:  map[string]struct{}{},
+	}
+	value := b.schema(code, false)
+	if typ.Kind() == reflect.Ptr && code.Kind() != CodeKindPtr {
+		// typeToCode removes the pointer of the root type
+		value = nullable(value)
+	}
+	schema := runtime.OrderedObject{{Key: "$schema", Value: SchemaDraft}}
+	schema = append(schema, value...)
+	if len(b.defs) > 0 {
+		schema = append(schema, runtime.OrderedObjectEntry{Key: "$defs", Value: b.defs})
+	}
+	return schema, nil
+}
+
+func (b *schemaBuilder) schema(code Code, nilAsEmpty bool) runtime.OrderedObject {
+	switch c := code.(type) {
+	case *IntCode:
+		return integerSchema(true, c.bitSize)
+	case *UintCode:
+		return integerSchema(false, c.bitSize)
+	case *FloatCode:
+		return typeSchema("number")
+	case *StringCode:
+		if c.typ == runtime.Type2RType(jsonNumberType) {
+			// json.Number is written as a bare number
+			return typeSchema("number")
+		}
+		return typeSchema("string")
+	case *BoolCode:
+		return typeSchema("boolean")
+	case *BytesCode:
+		return nullableIf(bytesSchema(runtime.BytesEncodingDefault), !nilAsEmpty)
+	case *TimeCode:
+		return timeSchema(c.format)
+	case *InterfaceCode:
+		return runtime.OrderedObject{}
+	case *PtrCode:
+		return nullable(b.schema(c.value, nilAsEmpty))
+	case *SliceCode:
+		return nullableIf(runtime.OrderedObject{
+			{Key: "type", Value: "array"},
+			{Key: "items", Value: b.schema(c.value, nilAsEmpty)},
+		}, !nilAsEmpty)
+	case *ChanCode:
+		return nullable(runtime.OrderedObject{
+			{Key: "type", Value: "array"},
+			{Key: "items", Value: b.schema(c.value, nilAsEmpty)},
+		})
+	case *ArrayCode:
+		return runtime.OrderedObject{
+			{Key: "type", Value: "array"},
+			{Key: "items", Value: b.schema(c.value, nilAsEmpty)},
+			{Key: "minItems", Value: c.typ.Len()},
+			{Key: "maxItems", Value: c.typ.Len()},
+		}
+	case *MapCode:
+		schema := runtime.OrderedObject{{Key: "type", Value: "object"}}
+		switch c.key.Kind() {
+		case CodeKindInt:
+			schema = append(schema, runtime.OrderedObjectEntry{Key: "propertyNames", Value: runtime.OrderedObject{{Key: "pattern", Value: "^-?[0-9]+$"}}})
+		case CodeKindUint:
+			schema = append(schema, runtime.OrderedObjectEntry{Key: "propertyNames", Value: runtime.OrderedObject{{Key: "pattern", Value: "^[0-9]+$"}}})
+		}
+		schema = append(schema, runtime.OrderedObjectEntry{Key: "additionalProperties", Value: b.schema(c.value, nilAsEmpty)})
+		return nullableIf(schema, !nilAsEmpty)
+	case *OrderedObjectCode:
+		return nullableIf(typeSchema("object"), !nilAsEmpty)
+	case *MarshalJSONCode:
+		if c.isBigNumber {
+			return nullable(typeSchema("number"))
+		}
+		// the encoded value is determined by MarshalJSON
+		return runtime.OrderedObject{}
+	case *MarshalTextCode:
+		return nullableIf(typeSchema("string"), c.typ.Kind() == reflect.Ptr)
+	case *StructCode:
+		return b.structSchema(c)
+	}
+	return runtime.OrderedObject{}
+}
+
+func (b *schemaBuilder) structSchema(code *StructCode) runtime.OrderedObject {
+	typ := runtime.RType2Type(code.typ)
+	if typ.Name() == "" {
+		return b.objectSchema(code)
+	}
+	name, exists := b.names[code.typ]
+	if !exists {
+		name = b.defName(typ)
+		b.names[code.typ] = name
+		// register the definition before building the fields for the recursive types
+		b.defs = append(b.defs, runtime.OrderedObjectEntry{Key: name})
+		idx := len(b.defs) - 1
+		b.defs[idx].Value = b.objectSchema(code)
+	}
+	return runtime.OrderedObject{{Key: "$ref", Value: "#/$defs/" + name}}
+}
+
+func (b *schemaBuilder) defName(typ reflect.Type) string {
+	name := sanitizeDefName(typ.Name())
+	if _, exists := b.used[name]; exists {
+		name = sanitizeDefName(typ.PkgPath() + "." + typ.Name())
+	}
+	for i := 2; ; i++ {
+		if _, exists := b.used[name]; !exists {
+			break
+		}
+		name = sanitizeDefName(typ.Name()) + strconv.Itoa(i)
+	}
+	b.used[name] = struct{}{}
+	return name
+}
+
+// sanitizeDefName replaces the characters that need escaping in $ref, such as the brackets of the generic types.
+func sanitizeDefName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (b *schemaBuilder) objectSchema(code *StructCode) runtime.OrderedObject {
+	properties := runtime.OrderedObject{}
+	required := []string{}
+	b.addProperties(&properties, &required, code, false)
+	schema := runtime.OrderedObject{
+		{Key: "type", Value: "object"},
+		{Key: "properties", Value: properties},
+	}
+	if len(required) > 0 {
+		schema = append(schema, runtime.OrderedObjectEntry{Key: "required", Value: required})
+	}
+	return append(schema, runtime.OrderedObjectEntry{Key: "additionalProperties", Value: false})
+}
+
+// addProperties adds the fields of code in the order of the encoder, with the fields of the embedded structs promoted.
+// The fields promoted through the embedded pointers are not required since they are omitted for nil.
+func (b *schemaBuilder) addProperties(properties *runtime.OrderedObject, required *[]string, code *StructCode, isOptional bool) {
+	for _, field := range code.fields {
+		if structCode := field.getAnonymousStruct(); structCode != nil && !structCode.isRecursive {
+			b.addProperties(properties, required, structCode, isOptional || field.value.Kind() == CodeKindPtr)
+			continue
+		}
+		properties.Set(field.key, b.fieldSchema(field))
+		if !isOptional && !(field.tag.IsOmitEmpty && isOmittableCode(field.value)) {
+			*required = append(*required, field.key)
+		}
+	}
+}
+
+func (b *schemaBuilder) fieldSchema(field *StructFieldCode) runtime.OrderedObject {
+	tag := field.tag
+	value := field.value
+	ptr, isPtr := value.(*PtrCode)
+	if isPtr {
+		value = ptr.value
+	}
+	var schema runtime.OrderedObject
+	switch c := value.(type) {
+	case *IntCode, *UintCode, *FloatCode, *StringCode, *BoolCode:
+		if tag.IsString {
+			// the value is quoted by the string option
+			schema = typeSchema("string")
+		}
+	case *BytesCode:
+		if enc, ok := runtime.BytesEncodingByName(tag.Format); ok {
+			schema = nullableIf(bytesSchema(enc), !tag.IsNilEmpty)
+		}
+	case *ArrayCode:
+		if enc, ok := runtime.BytesEncodingByName(tag.Format); ok && c.isByteArray && enc != runtime.BytesEncodingArray {
+			schema = bytesSchema(enc)
+		}
+	}
+	if schema == nil {
+		return b.schema(field.value, tag.IsNilEmpty)
+	}
+	if isPtr {
+		return nullable(schema)
+	}
+	return schema
+}
+
+// isOmittableCode reports whether the value of code is omitted for omitempty.
+// The arrays, the structs and time.Time are never omitted as the encoder does.
+func isOmittableCode(code Code) bool {
+	switch code.Kind() {
+	case CodeKindArray, CodeKindStruct, CodeKindTime:
+		return false
+	}
+	return true
+}
+
+func typeSchema(typ string) runtime.OrderedObject {
+	return runtime.OrderedObject{{Key: "type", Value: typ}}
+}
+
+func integerSchema(signed bool, bitSize uint8) runtime.OrderedObject {
+	schema := typeSchema("integer")
+	switch {
+	case signed && bitSize < 64:
+		schema = append(schema,
+			runtime.OrderedObjectEntry{Key: "minimum", Value: int64(-1) << (bitSize - 1)},
+			runtime.OrderedObjectEntry{Key: "maximum", Value: int64(1)<<(bitSize-1) - 1},
+		)
+	case !signed && bitSize < 64:
+		schema = append(schema,
+			runtime.OrderedObjectEntry{Key: "minimum", Value: 0},
+			runtime.OrderedObjectEntry{Key: "maximum", Value: uint64(1)<<bitSize - 1},
+		)
+	case !signed:
+		schema = append(schema,
+			runtime.OrderedObjectEntry{Key: "minimum", Value: 0},
+			runtime.OrderedObjectEntry{Key: "maximum", Value: uint64(math.MaxUint64)},
+		)
+	}
+	return schema
+}
+
+func bytesSchema(enc runtime.BytesEncoding) runtime.OrderedObject {
+	switch enc {
+	case runtime.BytesEncodingArray:
+		return runtime.OrderedObject{
+			{Key: "type", Value: "array"},
+			{Key: "items", Value: integerSchema(false, 8)},
+		}
+	case runtime.BytesEncodingHex:
+		return runtime.OrderedObject{
+			{Key: "type", Value: "string"},
+			{Key: "contentEncoding", Value: "base16"},
+		}
+	case runtime.BytesEncodingBase64URL, runtime.BytesEncodingRawBase64URL:
+		return runtime.OrderedObject{
+			{Key: "type", Value: "string"},
+			{Key: "contentEncoding", Value: "base64url"},
+		}
+	}
+	return runtime.OrderedObject{
+		{Key: "type", Value: "string"},
+		{Key: "contentEncoding", Value: "base64"},
+	}
+}
+
+// timeSchema returns the schema of time.Time for the format option of the struct tag.
+func timeSchema(format string) runtime.OrderedObject {
+	if format == "" {
+		format = time.RFC3339Nano
+	}
+	if _, ok := runtime.UnixTimeUnit(format); ok {
+		return typeSchema("integer")
+	}
+	schema := typeSchema("string")
+	switch format {
+	case time.RFC3339, time.RFC3339Nano:
+		schema = append(schema, runtime.OrderedObjectEntry{Key: "format", Value: "date-time"})
+	case "2006-01-02":
+		schema = append(schema, runtime.OrderedObjectEntry{Key: "format", Value: "date"})
+	}
+	return schema
+}
+
+// nullable adds null to the types accepted by schema.
+func nullable(schema runtime.OrderedObject) runtime.OrderedObject {
+	if len(schema) == 0 {
+		// any value including null
+		return schema
+	}
+	if typ, ok := schema.Get("type"); ok {
+		switch t := typ.(type) {
+		case string:
+			if t == "null" {
+				return schema
+			}
+			result := make(runtime.OrderedObject, len(schema))
+			copy(result, schema)
+			result.Set("type", []string{t, "null"})
+			return result
+		case []string:
+			return schema
+		}
+	}
+	return runtime.OrderedObject{
+		{Key: "anyOf", Value: []runtime.OrderedObject{schema, typeSchema("null")}},
+	}
+}
+
+func nullableIf(schema runtime.OrderedObject, cond bool) runtime.OrderedObject {
+	if cond {
+		return nullable(schema)
+	}
+	return schema
+}
@@ -1,15 +1,10 @@
 package encoder
 
 import (
-	"math/bits"
-	"reflect"
+	"unicode/utf16"
 	"unicode/utf8"
-	"unsafe"
-)
 
-const (
-	lsb = 0x0101010101010101
-	msb = 0x8080808080808080
+	"github.com/goccy/go-json/internal/simd"
 )
 
 var needEscapeWithHTML = [256]bool{
@@ -349,65 +344,9 @@ var needEscape = [256]bool{
 
 var hex = "0123456789abcdef"
 
-// escapeIndex finds the index of the first char in `s` that requires escaping.
-// A char requires escaping if it's outside of the range of [0x20, 0x7F] or if
-// it includes a double quote or backslash.
-// If no chars in `s` require escaping, the return value is -1.
-func escapeIndex(s string) int {
-	chunks := stringToUint64Slice(s)
-	for _, n := range chunks {
-		// combine masks before checking for the MSB of each byte. We include
-		// `n` in the mask to check whether any of the *input* byte MSBs were
-		// set (i.e. the byte was outside the ASCII range).
-		mask := n | below(n, 0x20) | contains(n, '"') | contains(n, '\\')
-		if (mask & msb) != 0 {
-			return bits.TrailingZeros64(mask&msb) / 8
-		}
-	}
-
-	valLen := len(s)
-	for i := len(chunks) * 8; i < valLen; i++ {
-		if needEscape[s[i]] {
-			return i
-		}
-	}
-
-	return -1
-}
-
-// below return a mask that can be used to determine if any of the bytes
-// in `n` are below `b`. If a byte's MSB is set in the mask then that byte was
-// below `b`. The result is only valid if `b`, and each byte in `n`, is below
-// 0x80.
-func below(n uint64, b byte) uint64 {
-	return n - expand(b)
-}
-
-// contains returns a mask that can be used to determine if any of the
-// bytes in `n` are equal to `b`. If a byte's MSB is set in the mask then
-// that byte is equal to `b`. The result is only valid if `b`, and each
-// byte in `n`, is below 0x80.
-func contains(n uint64, b byte) uint64 {
-	return (n ^ expand(b)) - lsb
-}
-
-// expand puts the specified byte into each of the 8 bytes of a uint64.
-func expand(b byte) uint64 {
-	return lsb * uint64(b)
-}
-
-//nolint:govet
-func stringToUint64Slice(s string) []uint64 {
-	return *(*[]uint64)(unsafe.Pointer(&reflect.SliceHeader{
-		Data: ((*reflect.StringHeader)(unsafe.Pointer(&s))).Data,
-		Len:  len(s) / 8,
-		Cap:  len(s) / 8,
-	}))
-}
-
 func AppendString(ctx *RuntimeContext, buf []byte, s string) []byte {
 	if ctx.Option.Flag&HTMLEscapeOption == 0 {
-		return appendString(buf, s)
+		return appendString(buf, s, ctx.Option.Flag&stringEscapeOptions)
 	}
 	valLen := len(s)
 	if valLen == 0 {
@@ -418,32 +357,11 @@ func AppendString(ctx *RuntimeContext, buf []byte, s string) []byte {
 		i, j int
 	)
 	if valLen >= 8 {
-		chunks := stringToUint64Slice(s)
-		for _, n := range chunks {
-			// combine masks before checking for the MSB of each byte. We include
-			// `n` in the mask to check whether any of the *input* byte MSBs were
-			// set (i.e. the byte was outside the ASCII range).
-			mask := n | (n - (lsb * 0x20)) |
-				((n ^ (lsb * '"')) - lsb) |
-				((n ^ (lsb * '\\')) - lsb) |
-				((n ^ (lsb * '<')) - lsb) |
-				((n ^ (lsb * '>')) - lsb) |
-				((n ^ (lsb * '&')) - lsb)
-			if (mask & msb) != 0 {
-				j = bits.TrailingZeros64(mask&msb) / 8
-				goto ESCAPE_END
-			}
-		}
-		for i := len(chunks) * 8; i < valLen; i++ {
-			if needEscapeWithHTML[s[i]] {
-				j = i
-				goto ESCAPE_END
-			}
+		if j = simd.IndexEscape(s, true); j < 0 {
+			// no found any escape characters.
+			return append(append(buf, s...), '"')
 		}
-		// no found any escape characters.
-		return append(append(buf, s...), '"')
 	}
-ESCAPE_END:
 	for j < valLen {
 		c := s[j]
 
@@ -534,7 +452,10 @@ ESCAPE_END:
 	return append(append(buf, s[i:]...), '"')
 }
 
-func appendString(buf []byte, s string) []byte {
+// appendString escapes `s` without HTML escaping.
+// U+2028 and U+2029 are escaped unless EscapeMinimalOption is specified,
+// and EscapeASCIIOption escapes all non-ASCII characters.
+func appendString(buf []byte, s string, policy OptionFlag) []byte {
 	valLen := len(s)
 	if valLen == 0 {
 		return append(buf, `""`...)
@@ -542,7 +463,7 @@ func appendString(buf []byte, s string) []byte {
 	buf = append(buf, '"')
 	var escapeIdx int
 	if valLen >= 8 {
-		if escapeIdx = escapeIndex(s); escapeIdx < 0 {
+		if escapeIdx = simd.IndexEscape(s, false); escapeIdx < 0 {
 			return append(append(buf, s...), '"')
 		}
 	}
@@ -616,14 +537,25 @@ func appendString(buf []byte, s string) []byte {
 			continue
 		}
 
+		if (policy & EscapeASCIIOption) != 0 {
+			buf = append(buf, s[i:j]...)
+			buf = appendEscapedRune(buf, r)
+			i = j + size
+			j = j + size
+			continue
+		}
+
 		switch r {
 		case '\u2028', '\u2029':
+			if (policy & EscapeMinimalOption) != 0 {
+				break
+			}
 			// U+2028 is LINE SEPARATOR.
 			// U+2029 is PARAGRAPH SEPARATOR.
 			// They are both technically valid characters in JSON strings,
 			// but don't work in JSONP, which has to be evaluated as JavaScript,
 			// and can lead to security holes there. It is valid JSON to
-			// escape them, so we do so unconditionally.
+			// escape them, so we do so unless EscapeMinimalOption is specified.
 			// See http://timelessrepo.com/json-isnt-a-javascript-subset for discussion.
 			buf = append(buf, s[i:j]...)
 			buf = append(buf, `\u202`...)
@@ -638,3 +570,12 @@ func appendString(buf []byte, s string) []byte {
 
 	return append(append(buf, s[i:]...), '"')
 }
+
+// appendEscapedRune appends r as \uXXXX. Runes outside of the BMP are written as a surrogate pair.
+func appendEscapedRune(buf []byte, r rune) []byte {
+	if r > 0xffff {
+		r1, r2 := utf16.EncodeRune(r)
+		return appendEscapedRune(appendEscapedRune(buf, r1), r2)
+	}
+	return append(buf, '\\', 'u', hex[r>>12&0xF], hex[r>>8&0xF], hex[r>>4&0xF], hex[r&0xF])
+}
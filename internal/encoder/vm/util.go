@@ -3,6 +3,7 @@ package vm
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/encoder"
@@ -12,20 +13,27 @@ import (
 const uintptrSize = 4 << (^uintptr(0) >> 63)
 
 var (
-	appendInt           = encoder.AppendInt
-	appendUint          = encoder.AppendUint
-	appendFloat32       = encoder.AppendFloat32
-	appendFloat64       = encoder.AppendFloat64
-	appendString        = encoder.AppendString
-	appendByteSlice     = encoder.AppendByteSlice
-	appendNumber        = encoder.AppendNumber
-	errUnsupportedValue = encoder.ErrUnsupportedValue
-	errUnsupportedFloat = encoder.ErrUnsupportedFloat
-	mapiterinit         = encoder.MapIterInit
-	mapiterkey          = encoder.MapIterKey
-	mapitervalue        = encoder.MapIterValue
-	mapiternext         = encoder.MapIterNext
-	maplen              = encoder.MapLen
+	appendInt             = encoder.AppendInt
+	appendUint            = encoder.AppendUint
+	appendFloat32         = encoder.AppendFloat32
+	appendFloat64         = encoder.AppendFloat64
+	appendFloat32String   = encoder.AppendFloat32String
+	appendFloat64String   = encoder.AppendFloat64String
+	appendString          = encoder.AppendString
+	appendByteSlice       = encoder.AppendByteSlice
+	appendNumber          = encoder.AppendNumber
+	appendTime            = encoder.AppendTime
+	errUnsupportedValue   = encoder.ErrUnsupportedValue
+	errUnsupportedFloat   = encoder.ErrUnsupportedFloat
+	errUnsupportedFloat32 = encoder.ErrUnsupportedFloat32
+	isUnsupportedFloat    = encoder.IsUnsupportedFloat
+	isEncodedByteArray    = encoder.IsEncodedByteArray
+	isNilAsEmpty          = encoder.IsNilAsEmpty
+	mapiterinit           = encoder.MapIterInit
+	mapiterkey            = encoder.MapIterKey
+	mapitervalue          = encoder.MapIterValue
+	mapiternext           = encoder.MapIterNext
+	maplen                = encoder.MapLen
 )
 
 type emptyInterface struct {
@@ -88,6 +96,14 @@ func ptrToBytes(p uintptr) []byte               { return **(**[]byte)(unsafe.Poi
 func ptrToNumber(p uintptr) json.Number         { return **(**json.Number)(unsafe.Pointer(&p)) }
 func ptrToString(p uintptr) string              { return **(**string)(unsafe.Pointer(&p)) }
 func ptrToSlice(p uintptr) *runtime.SliceHeader { return *(**runtime.SliceHeader)(unsafe.Pointer(&p)) }
+func ptrToTime(p uintptr) time.Time             { return **(**time.Time)(unsafe.Pointer(&p)) }
+func ptrToByteArray(p uintptr, length uint32) []byte {
+	return *(*[]byte)(unsafe.Pointer(&runtime.SliceHeader{
+		Data: ptrToUnsafePtr(p),
+		Len:  int(length),
+		Cap:  int(length),
+	}))
+}
 func ptrToPtr(p uintptr) uintptr {
 	return uintptr(**(**unsafe.Pointer)(unsafe.Pointer(&p)))
 }
@@ -188,6 +204,11 @@ func appendStructKey(_ *encoder.RuntimeContext, code *encoder.Opcode, b []byte)
 	return append(b, code.Key...)
 }
 
+func appendObjectKey(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte, key string) []byte {
+	b = encoder.AppendString(ctx, b, key)
+	return append(b, ':')
+}
+
 func appendStructEnd(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
 	return append(b, '}', ',')
 }
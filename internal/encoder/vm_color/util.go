@@ -3,6 +3,7 @@ package vm_color
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/encoder"
@@ -12,13 +13,17 @@ import (
 const uintptrSize = 4 << (^uintptr(0) >> 63)
 
 var (
-	errUnsupportedValue = encoder.ErrUnsupportedValue
-	errUnsupportedFloat = encoder.ErrUnsupportedFloat
-	mapiterinit         = encoder.MapIterInit
-	mapiterkey          = encoder.MapIterKey
-	mapitervalue        = encoder.MapIterValue
-	mapiternext         = encoder.MapIterNext
-	maplen              = encoder.MapLen
+	errUnsupportedValue   = encoder.ErrUnsupportedValue
+	errUnsupportedFloat   = encoder.ErrUnsupportedFloat
+	errUnsupportedFloat32 = encoder.ErrUnsupportedFloat32
+	isUnsupportedFloat    = encoder.IsUnsupportedFloat
+	isEncodedByteArray    = encoder.IsEncodedByteArray
+	isNilAsEmpty          = encoder.IsNilAsEmpty
+	mapiterinit           = encoder.MapIterInit
+	mapiterkey            = encoder.MapIterKey
+	mapitervalue          = encoder.MapIterValue
+	mapiternext           = encoder.MapIterNext
+	maplen                = encoder.MapLen
 )
 
 type emptyInterface struct {
@@ -81,6 +86,14 @@ func ptrToBytes(p uintptr) []byte               { return **(**[]byte)(unsafe.Poi
 func ptrToNumber(p uintptr) json.Number         { return **(**json.Number)(unsafe.Pointer(&p)) }
 func ptrToString(p uintptr) string              { return **(**string)(unsafe.Pointer(&p)) }
 func ptrToSlice(p uintptr) *runtime.SliceHeader { return *(**runtime.SliceHeader)(unsafe.Pointer(&p)) }
+func ptrToTime(p uintptr) time.Time             { return **(**time.Time)(unsafe.Pointer(&p)) }
+func ptrToByteArray(p uintptr, length uint32) []byte {
+	return *(*[]byte)(unsafe.Pointer(&runtime.SliceHeader{
+		Data: ptrToUnsafePtr(p),
+		Len:  int(length),
+		Cap:  int(length),
+	}))
+}
 func ptrToPtr(p uintptr) uintptr {
 	return uintptr(**(**unsafe.Pointer)(unsafe.Pointer(&p)))
 }
@@ -118,17 +131,31 @@ func appendUint(ctx *encoder.RuntimeContext, b []byte, p uintptr, code *encoder.
 	return append(b, format.Footer...)
 }
 
-func appendFloat32(ctx *encoder.RuntimeContext, b []byte, v float32) []byte {
+func appendFloat32(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v float32) []byte {
+	format := ctx.Option.ColorScheme.Float
+	b = append(b, format.Header...)
+	b = encoder.AppendFloat32(ctx, code, b, v)
+	return append(b, format.Footer...)
+}
+
+func appendFloat32String(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v float32) []byte {
 	format := ctx.Option.ColorScheme.Float
 	b = append(b, format.Header...)
-	b = encoder.AppendFloat32(ctx, b, v)
+	b = encoder.AppendFloat32String(ctx, code, b, v)
 	return append(b, format.Footer...)
 }
 
-func appendFloat64(ctx *encoder.RuntimeContext, b []byte, v float64) []byte {
+func appendFloat64(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v float64) []byte {
 	format := ctx.Option.ColorScheme.Float
 	b = append(b, format.Header...)
-	b = encoder.AppendFloat64(ctx, b, v)
+	b = encoder.AppendFloat64(ctx, code, b, v)
+	return append(b, format.Footer...)
+}
+
+func appendFloat64String(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v float64) []byte {
+	format := ctx.Option.ColorScheme.Float
+	b = append(b, format.Header...)
+	b = encoder.AppendFloat64String(ctx, code, b, v)
 	return append(b, format.Footer...)
 }
 
@@ -139,10 +166,10 @@ func appendString(ctx *encoder.RuntimeContext, b []byte, v string) []byte {
 	return append(b, format.Footer...)
 }
 
-func appendByteSlice(ctx *encoder.RuntimeContext, b []byte, src []byte) []byte {
+func appendByteSlice(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, src []byte) []byte {
 	format := ctx.Option.ColorScheme.Binary
 	b = append(b, format.Header...)
-	b = encoder.AppendByteSlice(ctx, b, src)
+	b = encoder.AppendByteSlice(ctx, code, b, src)
 	return append(b, format.Footer...)
 }
 
@@ -208,6 +235,19 @@ func appendMarshalJSON(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []by
 	return encoder.AppendMarshalJSON(ctx, code, b, v)
 }
 
+func appendTime(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v time.Time) ([]byte, error) {
+	format := ctx.Option.ColorScheme.String
+	if _, ok := runtime.UnixTimeUnit(encoder.TimeFormat(ctx, code)); ok {
+		format = ctx.Option.ColorScheme.Int
+	}
+	b = append(b, format.Header...)
+	bb, err := encoder.AppendTime(ctx, code, b, v)
+	if err != nil {
+		return nil, err
+	}
+	return append(bb, format.Footer...), nil
+}
+
 func appendMarshalText(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v interface{}) ([]byte, error) {
 	format := ctx.Option.ColorScheme.String
 	b = append(b, format.Header...)
@@ -255,6 +295,15 @@ func appendStructKey(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte
 	return append(b, ':')
 }
 
+func appendObjectKey(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte, key string) []byte {
+	format := ctx.Option.ColorScheme.ObjectKey
+	b = append(b, format.Header...)
+	b = encoder.AppendString(ctx, b, key)
+	b = append(b, format.Footer...)
+
+	return append(b, ':')
+}
+
 func appendStructEnd(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
 	return append(b, '}', ',')
 }
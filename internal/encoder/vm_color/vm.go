@@ -2,8 +2,7 @@
 package vm_color
 
 import (
-	"math"
-	"sort"
+	"reflect"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/encoder"
@@ -17,6 +16,8 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 	var code *encoder.Opcode
 	if (ctx.Option.Flag & encoder.HTMLEscapeOption) != 0 {
 		code = codeSet.EscapeKeyCode
+	} else if (ctx.Option.Flag & encoder.EscapeASCIIOption) != 0 {
+		code = codeSet.ASCIIEscapeKeyCode
 	} else {
 		code = codeSet.NoescapeKeyCode
 	}
@@ -78,7 +79,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			store(ctxptr, code.Idx, p)
 			fallthrough
 		case encoder.OpFloat32:
-			b = appendFloat32(ctx, b, ptrToFloat32(load(ctxptr, code.Idx)))
+			v := ptrToFloat32(load(ctxptr, code.Idx))
+			if isUnsupportedFloat(ctx, float64(v)) {
+				return nil, errUnsupportedFloat32(v)
+			}
+			b = appendFloat32(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpFloat64Ptr:
@@ -92,10 +97,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			fallthrough
 		case encoder.OpFloat64:
 			v := ptrToFloat64(load(ctxptr, code.Idx))
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
-			b = appendFloat64(ctx, b, v)
+			b = appendFloat64(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStringPtr:
@@ -134,7 +139,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			store(ctxptr, code.Idx, p)
 			fallthrough
 		case encoder.OpBytes:
-			b = appendByteSlice(ctx, b, ptrToBytes(load(ctxptr, code.Idx)))
+			b = appendByteSlice(ctx, code, b, ptrToBytes(load(ctxptr, code.Idx)))
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpNumberPtr:
@@ -194,7 +199,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				typ = iface.typ
 			}
 			if ifacePtr == nil {
-				b = appendNullComma(ctx, b)
+				if typ != nil && typ.Kind() == reflect.Map && isNilAsEmpty(ctx, code) {
+					b = appendEmptyObject(ctx, b)
+				} else {
+					b = appendNullComma(ctx, b)
+				}
 				code = code.Next
 				break
 			}
@@ -210,6 +219,8 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			var c *encoder.Opcode
 			if (ctx.Option.Flag & encoder.HTMLEscapeOption) != 0 {
 				c = ifaceCodeSet.InterfaceEscapeKeyCode
+			} else if (ctx.Option.Flag & encoder.EscapeASCIIOption) != 0 {
+				c = ifaceCodeSet.InterfaceASCIIEscapeKeyCode
 			} else {
 				c = ifaceCodeSet.InterfaceNoescapeKeyCode
 			}
@@ -297,6 +308,22 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			b = appendComma(ctx, bb)
 			code = code.Next
+		case encoder.OpTimePtr:
+			p := loadNPtr(ctxptr, code.Idx, code.PtrNum)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.Next
+				break
+			}
+			store(ctxptr, code.Idx, p)
+			fallthrough
+		case encoder.OpTime:
+			bb, err := appendTime(ctx, code, b, ptrToTime(load(ctxptr, code.Idx)))
+			if err != nil {
+				return nil, err
+			}
+			b = appendComma(ctx, bb)
+			code = code.Next
 		case encoder.OpSlicePtr:
 			p := loadNPtr(ctxptr, code.Idx, code.PtrNum)
 			if p == 0 {
@@ -310,7 +337,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p := load(ctxptr, code.Idx)
 			slice := ptrToSlice(p)
 			if p == 0 || slice.Data == nil {
-				b = appendNullComma(ctx, b)
+				if p != 0 && isNilAsEmpty(ctx, code) {
+					b = appendEmptyArray(ctx, b)
+				} else {
+					b = appendNullComma(ctx, b)
+				}
 				code = code.End.Next
 				break
 			}
@@ -326,6 +357,13 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 			}
 		case encoder.OpSliceElem:
+			if (ctx.Option.Flag&encoder.FlushOption) != 0 && len(b) >= ctx.FlushThreshold {
+				bb, err := ctx.Flush(b)
+				if err != nil {
+					return nil, err
+				}
+				b = bb
+			}
 			idx := load(ctxptr, code.ElemIdx)
 			length := load(ctxptr, code.Length)
 			idx++
@@ -340,6 +378,53 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendArrayEnd(ctx, code, b)
 				code = code.End.Next
 			}
+		case encoder.OpChanPtr, encoder.OpChan:
+			p := load(ctxptr, code.Idx)
+			if p != 0 && (code.Flags&encoder.IndirectFlags) != 0 {
+				p = ptrToPtr(p)
+			}
+			p = ptrToNPtr(p, code.PtrNum)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.End.Next
+				break
+			}
+			iter := encoder.NewIterator(ctx, code, p)
+			ctx.KeepRefs = append(ctx.KeepRefs, unsafe.Pointer(iter))
+			store(ctxptr, code.ElemIdx, uintptr(unsafe.Pointer(iter)))
+			elem, ok, err := iter.Next()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				b = appendArrayHead(ctx, code, b)
+				code = code.Next
+				store(ctxptr, code.Idx, elem)
+			} else {
+				b = appendEmptyArray(ctx, b)
+				code = code.End.Next
+			}
+		case encoder.OpChanElem:
+			if (ctx.Option.Flag&encoder.FlushOption) != 0 && len(b) >= ctx.FlushThreshold {
+				bb, err := ctx.Flush(b)
+				if err != nil {
+					return nil, err
+				}
+				b = bb
+			}
+			iter := (*encoder.Iterator)(ptrToUnsafePtr(load(ctxptr, code.ElemIdx)))
+			elem, ok, err := iter.Next()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				b = appendArrayElemIndent(ctx, code, b)
+				code = code.Next
+				store(ctxptr, code.Idx, elem)
+			} else {
+				b = appendArrayEnd(ctx, code, b)
+				code = code.End.Next
+			}
 		case encoder.OpArrayPtr:
 			p := loadNPtr(ctxptr, code.Idx, code.PtrNum)
 			if p == 0 {
@@ -356,6 +441,12 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 				break
 			}
+			if (code.Flags&encoder.ByteArrayFlags) != 0 && isEncodedByteArray(ctx, code) {
+				b = appendByteSlice(ctx, code, b, ptrToByteArray(p, code.Length))
+				b = appendComma(ctx, b)
+				code = code.End.Next
+				break
+			}
 			if code.Length > 0 {
 				b = appendArrayHead(ctx, code, b)
 				store(ctxptr, code.ElemIdx, 0)
@@ -366,6 +457,13 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 			}
 		case encoder.OpArrayElem:
+			if (ctx.Option.Flag&encoder.FlushOption) != 0 && len(b) >= ctx.FlushThreshold {
+				bb, err := ctx.Flush(b)
+				if err != nil {
+					return nil, err
+				}
+				b = bb
+			}
 			idx := load(ctxptr, code.ElemIdx)
 			idx++
 			if idx < uintptr(code.Length) {
@@ -391,7 +489,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpMap:
 			p := load(ctxptr, code.Idx)
 			if p == 0 {
-				b = appendNullComma(ctx, b)
+				if isNilAsEmpty(ctx, code) {
+					b = appendEmptyObject(ctx, b)
+				} else {
+					b = appendNullComma(ctx, b)
+				}
 				code = code.End.Next
 				break
 			}
@@ -413,6 +515,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			} else {
 				mapCtx := encoder.NewMapContext(mlen)
 				mapCtx.Pos = append(mapCtx.Pos, len(b))
+				ctx.SortedMapDepth++
 				ctx.KeepRefs = append(ctx.KeepRefs, unsafe.Pointer(mapCtx))
 				store(ctxptr, code.End.MapPos, uintptr(unsafe.Pointer(mapCtx)))
 			}
@@ -424,6 +527,13 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			length := load(ctxptr, code.Length)
 			idx++
 			if (ctx.Option.Flag & encoder.UnorderedMapOption) != 0 {
+				if (ctx.Option.Flag&encoder.FlushOption) != 0 && len(b) >= ctx.FlushThreshold {
+					bb, err := ctx.Flush(b)
+					if err != nil {
+						return nil, err
+					}
+					b = bb
+				}
 				if idx < length {
 					b = appendMapKeyIndent(ctx, code, b)
 					store(ctxptr, code.ElemIdx, idx)
@@ -467,6 +577,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			code = code.Next
 		case encoder.OpMapEnd:
 			// this operation only used by sorted map.
+			ctx.SortedMapDepth--
 			length := int(load(ctxptr, code.Length))
 			ptr := load(ctxptr, code.MapPos)
 			mapCtx := (*encoder.MapContext)(ptrToUnsafePtr(ptr))
@@ -485,7 +596,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 					Value: b[startValue:endValue],
 				})
 			}
-			sort.Sort(mapCtx.Slice)
+			mapCtx.Slice.Sort(ctx)
 			buf := mapCtx.Buf
 			for _, item := range mapCtx.Slice.Items {
 				buf = appendMapKeyValue(ctx, code, buf, item.Key, item.Value)
@@ -496,6 +607,54 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			mapCtx.Buf = buf
 			encoder.ReleaseMapContext(mapCtx)
 			code = code.Next
+		case encoder.OpOrderedObjectPtr:
+			p := loadNPtr(ctxptr, code.Idx, code.PtrNum)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.End.Next
+				break
+			}
+			store(ctxptr, code.Idx, p)
+			fallthrough
+		case encoder.OpOrderedObject:
+			p := load(ctxptr, code.Idx)
+			slice := ptrToSlice(p)
+			if p == 0 || slice.Data == nil {
+				if p != 0 && isNilAsEmpty(ctx, code) {
+					b = appendEmptyObject(ctx, b)
+				} else {
+					b = appendNullComma(ctx, b)
+				}
+				code = code.End.Next
+				break
+			}
+			if slice.Len == 0 {
+				b = appendEmptyObject(ctx, b)
+				code = code.End.Next
+				break
+			}
+			b = appendStructHead(ctx, b)
+			store(ctxptr, code.ElemIdx, 0)
+			store(ctxptr, code.Length, uintptr(slice.Len))
+			store(ctxptr, code.Idx, uintptr(slice.Data))
+			code = code.Next
+		case encoder.OpOrderedObjectKey:
+			idx := load(ctxptr, code.ElemIdx)
+			entry := load(ctxptr, code.Idx) + idx*uintptr(code.Size)
+			b = appendObjectKey(ctx, code, b, ptrToString(entry))
+			store(ctxptr, code.Next.Idx, entry+uintptr(code.Offset))
+			code = code.Next
+		case encoder.OpOrderedObjectElem:
+			idx := load(ctxptr, code.ElemIdx)
+			length := load(ctxptr, code.Length)
+			idx++
+			if idx < length {
+				store(ctxptr, code.ElemIdx, idx)
+				code = code.Next
+			} else {
+				b = appendMapEnd(ctx, code, b)
+				code = code.End.Next
+			}
 		case encoder.OpRecursivePtr:
 			p := load(ctxptr, code.Idx)
 			if p == 0 {
@@ -604,7 +763,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			p += uintptr(code.Offset)
-			if p == 0 || (ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) {
+			if p == 0 || ((code.Flags&encoder.IndirectFlags) != 0 && ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) {
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
@@ -1173,7 +1332,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = appendFloat32(ctx, b, ptrToFloat32(p+uintptr(code.Offset)))
+			v := ptrToFloat32(p + uintptr(code.Offset))
+			if isUnsupportedFloat(ctx, float64(v)) {
+				return nil, errUnsupportedFloat32(v)
+			}
+			b = appendFloat32(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructPtrHeadOmitEmptyFloat32:
@@ -1206,7 +1369,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat32(ctx, b, v)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -1236,9 +1402,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendFloat32(ctx, b, ptrToFloat32(p+uintptr(code.Offset)))
-			b = append(b, '"')
+			v := ptrToFloat32(p + uintptr(code.Offset))
+			if isUnsupportedFloat(ctx, float64(v)) {
+				return nil, errUnsupportedFloat32(v)
+			}
+			b = appendFloat32String(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructPtrHeadOmitEmptyFloat32String:
@@ -1271,9 +1439,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, v)
-				b = append(b, '"')
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -1307,7 +1476,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -1339,7 +1512,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -1373,9 +1550,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
-				b = append(b, '"')
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -1407,9 +1586,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
-				b = append(b, '"')
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -1436,14 +1617,14 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				break
 			}
 			v := ptrToFloat64(p + uintptr(code.Offset))
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
 			if code.Flags&encoder.AnonymousHeadFlags == 0 {
 				b = appendStructHead(ctx, b)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = appendFloat64(ctx, b, v)
+			b = appendFloat64(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructPtrHeadOmitEmptyFloat64:
@@ -1475,11 +1656,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if v == 0 {
 				code = code.NextField
 			} else {
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat64(ctx, b, v)
+				b = appendFloat64(ctx, code, b, v)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -1509,13 +1690,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			v := ptrToFloat64(p + uintptr(code.Offset))
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendFloat64(ctx, b, v)
-			b = append(b, '"')
+			b = appendFloat64String(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructPtrHeadOmitEmptyFloat64String:
@@ -1547,13 +1726,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if v == 0 {
 				code = code.NextField
 			} else {
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -1588,10 +1765,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendNull(ctx, b)
 			} else {
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
+				b = appendFloat64(ctx, code, b, v)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -1624,10 +1801,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
+				b = appendFloat64(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -1661,13 +1838,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -1699,13 +1874,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -2260,7 +2433,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = appendByteSlice(ctx, b, ptrToBytes(p+uintptr(code.Offset)))
+			b = appendByteSlice(ctx, code, b, ptrToBytes(p+uintptr(code.Offset)))
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructPtrHeadOmitEmptyBytes:
@@ -2293,7 +2466,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, v)
+				b = appendByteSlice(ctx, code, b, v)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -2327,7 +2500,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendByteSlice(ctx, code, b, ptrToBytes(p))
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -2359,7 +2532,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendByteSlice(ctx, code, b, ptrToBytes(p))
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3454,7 +3627,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructFieldFloat32:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = appendFloat32(ctx, b, ptrToFloat32(p+uintptr(code.Offset)))
+			v := ptrToFloat32(p + uintptr(code.Offset))
+			if isUnsupportedFloat(ctx, float64(v)) {
+				return nil, errUnsupportedFloat32(v)
+			}
+			b = appendFloat32(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructFieldOmitEmptyFloat32:
@@ -3462,16 +3639,21 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := ptrToFloat32(p + uintptr(code.Offset))
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat32(ctx, b, v)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
 		case encoder.OpStructFieldFloat32String:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendFloat32(ctx, b, ptrToFloat32(p+uintptr(code.Offset)))
-			b = append(b, '"')
+			v := ptrToFloat32(p + uintptr(code.Offset))
+			if isUnsupportedFloat(ctx, float64(v)) {
+				return nil, errUnsupportedFloat32(v)
+			}
+			b = appendFloat32String(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructFieldOmitEmptyFloat32String:
@@ -3479,9 +3661,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := ptrToFloat32(p + uintptr(code.Offset))
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, v)
-				b = append(b, '"')
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3492,7 +3675,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3501,7 +3688,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3512,9 +3703,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
-				b = append(b, '"')
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3523,9 +3716,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
-				b = append(b, '"')
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3533,47 +3728,43 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
 			v := ptrToFloat64(p + uintptr(code.Offset))
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
-			b = appendFloat64(ctx, b, v)
+			b = appendFloat64(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructFieldOmitEmptyFloat64:
 			p := load(ctxptr, code.Idx)
 			v := ptrToFloat64(p + uintptr(code.Offset))
 			if v != 0 {
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat64(ctx, b, v)
+				b = appendFloat64(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
 		case encoder.OpStructFieldFloat64String:
 			p := load(ctxptr, code.Idx)
 			v := ptrToFloat64(p + uintptr(code.Offset))
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendFloat64(ctx, b, v)
-			b = append(b, '"')
+			b = appendFloat64String(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructFieldOmitEmptyFloat64String:
 			p := load(ctxptr, code.Idx)
 			v := ptrToFloat64(p + uintptr(code.Offset))
 			if v != 0 {
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3587,10 +3778,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				break
 			}
 			v := ptrToFloat64(p)
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
-			b = appendFloat64(ctx, b, v)
+			b = appendFloat64(ctx, code, b, v)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructFieldOmitEmptyFloat64Ptr:
@@ -3599,10 +3790,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
+				b = appendFloat64(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3614,12 +3805,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendNull(ctx, b)
 			} else {
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = append(b, '"')
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3628,13 +3817,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3790,7 +3977,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructFieldBytes:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = appendByteSlice(ctx, b, ptrToBytes(p+uintptr(code.Offset)))
+			b = appendByteSlice(ctx, code, b, ptrToBytes(p+uintptr(code.Offset)))
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructFieldOmitEmptyBytes:
@@ -3798,7 +3985,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := ptrToBytes(p + uintptr(code.Offset))
 			if len(v) > 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, v)
+				b = appendByteSlice(ctx, code, b, v)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3809,7 +3996,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendByteSlice(ctx, code, b, ptrToBytes(p))
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3818,7 +4005,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendByteSlice(ctx, code, b, ptrToBytes(p))
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -4351,7 +4538,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndFloat32:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = appendFloat32(ctx, b, ptrToFloat32(p+uintptr(code.Offset)))
+			v := ptrToFloat32(p + uintptr(code.Offset))
+			if isUnsupportedFloat(ctx, float64(v)) {
+				return nil, errUnsupportedFloat32(v)
+			}
+			b = appendFloat32(ctx, code, b, v)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyFloat32:
@@ -4359,7 +4550,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := ptrToFloat32(p + uintptr(code.Offset))
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat32(ctx, b, v)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4368,9 +4562,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndFloat32String:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendFloat32(ctx, b, ptrToFloat32(p+uintptr(code.Offset)))
-			b = append(b, '"')
+			v := ptrToFloat32(p + uintptr(code.Offset))
+			if isUnsupportedFloat(ctx, float64(v)) {
+				return nil, errUnsupportedFloat32(v)
+			}
+			b = appendFloat32String(ctx, code, b, v)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyFloat32String:
@@ -4378,9 +4574,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := ptrToFloat32(p + uintptr(code.Offset))
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, v)
-				b = append(b, '"')
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4393,7 +4590,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 			}
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
@@ -4402,7 +4603,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4415,9 +4620,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
-				b = append(b, '"')
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 			}
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
@@ -4426,9 +4633,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat32(ctx, b, ptrToFloat32(p))
-				b = append(b, '"')
+				v := ptrToFloat32(p)
+				if isUnsupportedFloat(ctx, float64(v)) {
+					return nil, errUnsupportedFloat32(v)
+				}
+				b = appendFloat32String(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4437,22 +4646,22 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndFloat64:
 			p := load(ctxptr, code.Idx)
 			v := ptrToFloat64(p + uintptr(code.Offset))
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = appendFloat64(ctx, b, v)
+			b = appendFloat64(ctx, code, b, v)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyFloat64:
 			p := load(ctxptr, code.Idx)
 			v := ptrToFloat64(p + uintptr(code.Offset))
 			if v != 0 {
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
 				b = appendStructKey(ctx, code, b)
-				b = appendFloat64(ctx, b, v)
+				b = appendFloat64(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4461,26 +4670,22 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndFloat64String:
 			p := load(ctxptr, code.Idx)
 			v := ptrToFloat64(p + uintptr(code.Offset))
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendFloat64(ctx, b, v)
-			b = append(b, '"')
+			b = appendFloat64String(ctx, code, b, v)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyFloat64String:
 			p := load(ctxptr, code.Idx)
 			v := ptrToFloat64(p + uintptr(code.Offset))
 			if v != 0 {
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4497,10 +4702,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				break
 			}
 			v := ptrToFloat64(p)
-			if math.IsInf(v, 0) || math.IsNaN(v) {
+			if isUnsupportedFloat(ctx, v) {
 				return nil, errUnsupportedFloat(v)
 			}
-			b = appendFloat64(ctx, b, v)
+			b = appendFloat64(ctx, code, b, v)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyFloat64Ptr:
@@ -4509,10 +4714,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
+				b = appendFloat64(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4525,13 +4730,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 			}
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
@@ -4541,12 +4744,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
 				v := ptrToFloat64(p)
-				if math.IsInf(v, 0) || math.IsNaN(v) {
+				if isUnsupportedFloat(ctx, v) {
 					return nil, errUnsupportedFloat(v)
 				}
-				b = append(b, '"')
-				b = appendFloat64(ctx, b, v)
-				b = append(b, '"')
+				b = appendFloat64String(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4720,7 +4921,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndBytes:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = appendByteSlice(ctx, b, ptrToBytes(p+uintptr(code.Offset)))
+			b = appendByteSlice(ctx, code, b, ptrToBytes(p+uintptr(code.Offset)))
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyBytes:
@@ -4728,7 +4929,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := ptrToBytes(p + uintptr(code.Offset))
 			if len(v) > 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, v)
+				b = appendByteSlice(ctx, code, b, v)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4741,7 +4942,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendByteSlice(ctx, code, b, ptrToBytes(p))
 			}
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
@@ -4750,7 +4951,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendByteSlice(ctx, code, b, ptrToBytes(p))
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -10,6 +10,8 @@ func DebugRun(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet)
 	var code *encoder.Opcode
 	if (ctx.Option.Flag & encoder.HTMLEscapeOption) != 0 {
 		code = codeSet.EscapeKeyCode
+	} else if (ctx.Option.Flag & encoder.EscapeASCIIOption) != 0 {
+		code = codeSet.ASCIIEscapeKeyCode
 	} else {
 		code = codeSet.NoescapeKeyCode
 	}
@@ -109,6 +109,19 @@ func (e *UnsupportedValueError) Error() string {
 	return fmt.Sprintf("json: unsupported value: %s", e.Str)
 }
 
+// A LineError describes an error in a line of newline-delimited JSON.
+type LineError struct {
+	Line int   // 1-based line number of the input
+	Err  error // error occurred while decoding the line
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("json: line %d: %s", e.Line, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *LineError) Unwrap() error { return e.Err }
+
 func ErrSyntax(msg string, offset int64) *SyntaxError {
 	return &SyntaxError{msg: msg, Offset: offset}
 }
@@ -0,0 +1,52 @@
+package runtime
+
+import "encoding/base64"
+
+// BytesEncoding is the encoding of []byte and [N]byte values.
+type BytesEncoding uint8
+
+const (
+	// BytesEncodingDefault is padded standard base64 for []byte and an array of numbers for [N]byte.
+	BytesEncodingDefault BytesEncoding = iota
+	BytesEncodingBase64
+	BytesEncodingBase64URL
+	BytesEncodingRawBase64
+	BytesEncodingRawBase64URL
+	BytesEncodingHex
+	BytesEncodingArray
+)
+
+// BytesEncodingByName returns the encoding for name.
+// name is one of "base64", "base64url", "rawbase64", "rawbase64url", "hex" and "array".
+func BytesEncodingByName(name string) (BytesEncoding, bool) {
+	switch name {
+	case "base64":
+		return BytesEncodingBase64, true
+	case "base64url":
+		return BytesEncodingBase64URL, true
+	case "rawbase64":
+		return BytesEncodingRawBase64, true
+	case "rawbase64url":
+		return BytesEncodingRawBase64URL, true
+	case "hex":
+		return BytesEncodingHex, true
+	case "array":
+		return BytesEncodingArray, true
+	}
+	return BytesEncodingDefault, false
+}
+
+// Base64 returns the base64 encoding for e. It returns nil if e is not a base64 encoding.
+func (e BytesEncoding) Base64() *base64.Encoding {
+	switch e {
+	case BytesEncodingDefault, BytesEncodingBase64:
+		return base64.StdEncoding
+	case BytesEncodingBase64URL:
+		return base64.URLEncoding
+	case BytesEncodingRawBase64:
+		return base64.RawStdEncoding
+	case BytesEncodingRawBase64URL:
+		return base64.RawURLEncoding
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package runtime
+
+// OrderedObject is a JSON object that keeps its keys in insertion order.
+type OrderedObject []OrderedObjectEntry
+
+// OrderedObjectEntry is a key/value pair of OrderedObject.
+type OrderedObjectEntry struct {
+	Key   string
+	Value interface{}
+}
+
+// Get returns the value for key. If the object has duplicated keys, the last one wins as in map decoding.
+func (o OrderedObject) Get(key string) (interface{}, bool) {
+	for i := len(o) - 1; i >= 0; i-- {
+		if o[i].Key == key {
+			return o[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Set replaces the value for key, or appends a new entry if key does not exist.
+func (o *OrderedObject) Set(key string, value interface{}) {
+	obj := *o
+	for i := len(obj) - 1; i >= 0; i-- {
+		if obj[i].Key == key {
+			obj[i].Value = value
+			return
+		}
+	}
+	*o = append(obj, OrderedObjectEntry{Key: key, Value: value})
+}
+
+// Delete removes all entries for key.
+func (o *OrderedObject) Delete(key string) {
+	obj := *o
+	n := 0
+	for _, entry := range obj {
+		if entry.Key != key {
+			obj[n] = entry
+			n++
+		}
+	}
+	for i := n; i < len(obj); i++ {
+		obj[i] = OrderedObjectEntry{}
+	}
+	*o = obj[:n]
+}
+
+// Keys returns the keys in order.
+func (o OrderedObject) Keys() []string {
+	keys := make([]string, 0, len(o))
+	for _, entry := range o {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
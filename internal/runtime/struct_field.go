@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -30,6 +31,9 @@ type StructTag struct {
 	IsTaggedKey bool
 	IsOmitEmpty bool
 	IsString    bool
+	IsNilEmpty  bool   // encode nil slice and map as empty container instead of null
+	Precision   int    // number of digits for float value. -1 means the default
+	Format      string // format for time.Time or []byte value. empty means the default
 	Field       reflect.StructField
 }
 
@@ -64,7 +68,12 @@ func isValidTag(s string) bool {
 func StructTagFromField(field reflect.StructField) *StructTag {
 	keyName := field.Name
 	tag := getTag(field)
-	st := &StructTag{Field: field}
+	st := &StructTag{Field: field, Precision: -1}
+	// the format option takes the rest of the tag so that the format can contain commas
+	if idx := strings.Index(tag, ",format="); idx >= 0 {
+		st.Format = TimeLayout(tag[idx+len(",format="):])
+		tag = tag[:idx]
+	}
 	opts := strings.Split(tag, ",")
 	if len(opts) > 0 {
 		if opts[0] != "" && isValidTag(opts[0]) {
@@ -80,6 +89,15 @@ func StructTagFromField(field reflect.StructField) *StructTag {
 				st.IsOmitEmpty = true
 			case "string":
 				st.IsString = true
+			case "nilasempty":
+				st.IsNilEmpty = true
+			default:
+				if strings.HasPrefix(opt, "precision=") {
+					prec, err := strconv.ParseUint(strings.TrimPrefix(opt, "precision="), 10, 8)
+					if err == nil {
+						st.Precision = int(prec)
+					}
+				}
 			}
 		}
 	}
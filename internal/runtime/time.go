@@ -0,0 +1,51 @@
+package runtime
+
+import "time"
+
+// UnixTimeUnit returns the unit of the Unix time if format is one of "unix", "unixmilli", "unixmicro" and "unixnano".
+// Other formats are treated as layouts of time.Format.
+func UnixTimeUnit(format string) (time.Duration, bool) {
+	switch format {
+	case "unix":
+		return time.Second, true
+	case "unixmilli":
+		return time.Millisecond, true
+	case "unixmicro":
+		return time.Microsecond, true
+	case "unixnano":
+		return time.Nanosecond, true
+	}
+	return 0, false
+}
+
+// timeLayouts is the layouts of the time package that can be given by name,
+// since go vet reports the spaces contained in most of them when they are written in the json tag.
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    "2006-01-02 15:04:05",
+	"DateOnly":    "2006-01-02",
+	"TimeOnly":    "15:04:05",
+}
+
+// TimeLayout returns the layout if format is the name of a layout constant of the time package ( e.g. "RFC1123" ).
+// Other formats are returned as they are.
+func TimeLayout(format string) string {
+	if layout, ok := timeLayouts[format]; ok {
+		return layout
+	}
+	return format
+}
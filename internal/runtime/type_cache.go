@@ -0,0 +1,171 @@
+package runtime
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+const (
+	typeCacheShardBits = 4
+	typeCacheShards    = 1 << typeCacheShardBits
+	typeCacheMinSlots  = 8
+)
+
+// TypeCache is a concurrent hash map from *Type to a compiled value, used for the types outside of the range of TypeAddr
+// such as the types created by reflect.StructOf. Get does not lock and does not allocate,
+// and Store copies only the slots of a shard when it grows, so that inserts are amortized O(1).
+// If the limit is set, the oldest entries of a shard are evicted when it is full.
+type TypeCache struct {
+	shards [typeCacheShards]typeCacheShard
+	limit  int64 // per shard, 0 means unbounded
+}
+
+type typeCacheShard struct {
+	mu    sync.Mutex
+	table unsafe.Pointer // *typeCacheTable
+	order []*typeCacheEntry
+	_     [64]byte // avoid false sharing between shards
+}
+
+// typeCacheTable is an open addressing table whose slots are written once under the lock of the shard.
+// It is kept at most half full, so that a lookup always reaches an empty slot.
+type typeCacheTable struct {
+	mask  uintptr
+	slots []unsafe.Pointer // *typeCacheEntry
+}
+
+type typeCacheEntry struct {
+	typ   *Type
+	value interface{}
+}
+
+func typeCacheHash(typ *Type) uintptr {
+	// fibonacci hashing spreads the aligned addresses over the slots
+	return uintptr(uint64(uintptr(unsafe.Pointer(typ))) * 0x9E3779B97F4A7C15 >> 32)
+}
+
+func (c *TypeCache) shard(h uintptr) *typeCacheShard {
+	return &c.shards[h&(typeCacheShards-1)]
+}
+
+// Get returns the value stored for typ.
+func (c *TypeCache) Get(typ *Type) (interface{}, bool) {
+	h := typeCacheHash(typ)
+	t := (*typeCacheTable)(atomic.LoadPointer(&c.shard(h).table))
+	if t == nil {
+		return nil, false
+	}
+	for i := (h >> typeCacheShardBits) & t.mask; ; i = (i + 1) & t.mask {
+		e := (*typeCacheEntry)(atomic.LoadPointer(&t.slots[i]))
+		if e == nil {
+			return nil, false
+		}
+		if e.typ == typ {
+			return e.value, true
+		}
+	}
+}
+
+// Store stores value for typ. If typ is already stored by another goroutine, the stored value is kept and returned.
+func (c *TypeCache) Store(typ *Type, value interface{}) interface{} {
+	h := typeCacheHash(typ)
+	s := c.shard(h)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := (*typeCacheTable)(s.table)
+	if t != nil {
+		for i := (h >> typeCacheShardBits) & t.mask; ; i = (i + 1) & t.mask {
+			e := (*typeCacheEntry)(t.slots[i])
+			if e == nil {
+				break
+			}
+			if e.typ == typ {
+				return e.value
+			}
+		}
+	}
+	if limit := atomic.LoadInt64(&c.limit); limit > 0 && int64(len(s.order)) >= limit {
+		// drop the oldest quarter at once, so that the rebuilding is amortized
+		evict := len(s.order) - int(limit) + 1
+		if min := int(limit+3) / 4; evict < min {
+			evict = min
+		}
+		n := copy(s.order, s.order[evict:])
+		for i := n; i < len(s.order); i++ {
+			s.order[i] = nil
+		}
+		s.order = s.order[:n]
+		t = nil
+	}
+	entry := &typeCacheEntry{typ: typ, value: value}
+	s.order = append(s.order, entry)
+	if t == nil || len(s.order)*2 > len(t.slots) {
+		// readers keep using the old table until the new one is published
+		atomic.StorePointer(&s.table, unsafe.Pointer(newTypeCacheTable(s.order)))
+		return value
+	}
+	t.insert(entry, true)
+	return value
+}
+
+func newTypeCacheTable(entries []*typeCacheEntry) *typeCacheTable {
+	n := typeCacheMinSlots
+	for n < len(entries)*4 {
+		n <<= 1
+	}
+	t := &typeCacheTable{mask: uintptr(n - 1), slots: make([]unsafe.Pointer, n)}
+	for _, e := range entries {
+		t.insert(e, false)
+	}
+	return t
+}
+
+func (t *typeCacheTable) insert(e *typeCacheEntry, published bool) {
+	for i := (typeCacheHash(e.typ) >> typeCacheShardBits) & t.mask; ; i = (i + 1) & t.mask {
+		if t.slots[i] != nil {
+			continue
+		}
+		if published {
+			atomic.StorePointer(&t.slots[i], unsafe.Pointer(e))
+		} else {
+			t.slots[i] = unsafe.Pointer(e)
+		}
+		return
+	}
+}
+
+// SetLimit bounds the number of the stored types to about n, evicting the oldest ones. n <= 0 means unbounded.
+// The bound applies to each shard as n divided by the number of shards, and to the later stores.
+func (c *TypeCache) SetLimit(n int) {
+	var limit int64
+	if n > 0 {
+		limit = int64((n + typeCacheShards - 1) / typeCacheShards)
+	}
+	atomic.StoreInt64(&c.limit, limit)
+}
+
+// Range calls f for each stored type and value.
+func (c *TypeCache) Range(f func(typ *Type, value interface{})) {
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.Lock()
+		order := append([]*typeCacheEntry(nil), s.order...)
+		s.mu.Unlock()
+		for _, e := range order {
+			f(e.typ, e.value)
+		}
+	}
+}
+
+// Len returns the number of the stored types.
+func (c *TypeCache) Len() int {
+	n := 0
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.Lock()
+		n += len(s.order)
+		s.mu.Unlock()
+	}
+	return n
+}
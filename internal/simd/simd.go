@@ -0,0 +1,103 @@
+// Package simd provides the byte scanning used by the string encoders and decoders.
+// On amd64 they are implemented with SSE2 and AVX2 instructions for the bulk of the input,
+// and the pure Go implementations in this file, which read 8 bytes at once, are used for the rest and on the other architectures.
+package simd
+
+import (
+	"math/bits"
+	"unicode/utf8"
+	"unsafe"
+)
+
+const (
+	lsb = 0x0101010101010101
+	msb = 0x8080808080808080
+)
+
+// IndexEscape returns the index of the first byte of s that requires escaping in a JSON string,
+// that is a control character, '"', '\\' or a byte outside of ASCII. If html is true, '<', '>' and '&' are also reported.
+// It returns -1 if s has no such byte.
+func IndexEscape(s string, html bool) int {
+	return indexEscape(s, html)
+}
+
+// IndexStringEnd returns the index of the first '"', '\\' or nul in b, where a string literal being decoded ends or has an escape sequence.
+// It returns -1 if b has no such byte.
+func IndexStringEnd(b []byte) int {
+	return indexStringEnd(b)
+}
+
+// ValidUTF8 reports whether b is valid UTF-8. The ASCII runs are skipped by blocks.
+func ValidUTF8(b []byte) bool {
+	return InvalidUTF8Index(b) < 0
+}
+
+// InvalidUTF8Index returns the index of the first byte of b that is not a part of valid UTF-8 encoding, or -1 if b is valid UTF-8.
+func InvalidUTF8Index(b []byte) int {
+	i := 0
+	for {
+		i += asciiPrefix(b[i:])
+		if i == len(b) {
+			return -1
+		}
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size == 1 {
+			return i
+		}
+		i += size
+	}
+}
+
+func indexEscapeGeneric(s string, html bool) int {
+	n := len(s) / 8
+	for k := 0; k < n; k++ {
+		x := load64(s, k*8)
+		// x itself is included to find the bytes outside of ASCII, and the other masks are valid for ASCII
+		mask := x | (x - lsb*0x20) | ((x ^ lsb*'"') - lsb) | ((x ^ lsb*'\\') - lsb)
+		if html {
+			mask |= ((x ^ lsb*'<') - lsb) | ((x ^ lsb*'>') - lsb) | ((x ^ lsb*'&') - lsb)
+		}
+		if (mask & msb) != 0 {
+			return k*8 + bits.TrailingZeros64(mask&msb)/8
+		}
+	}
+	for i := n * 8; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c >= utf8.RuneSelf || c == '"' || c == '\\' || (html && (c == '<' || c == '>' || c == '&')) {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexStringEndGeneric(b []byte) int {
+	for i, c := range b {
+		switch c {
+		case '"', '\\', 0:
+			return i
+		}
+	}
+	return -1
+}
+
+func asciiPrefixGeneric(b []byte) int {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		if x := load64(*(*string)(unsafe.Pointer(&b)), i); x&msb != 0 {
+			return i + bits.TrailingZeros64(x&msb)/8
+		}
+	}
+	for ; i < len(b); i++ {
+		if b[i] >= utf8.RuneSelf {
+			return i
+		}
+	}
+	return i
+}
+
+// load64 reads 8 bytes of s from i in little endian.
+func load64(s string, i int) uint64 {
+	_ = s[i+7]
+	return uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+		uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+}
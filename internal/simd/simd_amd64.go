@@ -0,0 +1,115 @@
+package simd
+
+// useAVX2 reports whether the CPU and the OS support AVX2. SSE2 is always available on amd64.
+var useAVX2 = hasAVX2()
+
+func hasAVX2() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const (
+		osxsave = 1 << 27
+		avx     = 1 << 28
+	)
+	if ecx1&(osxsave|avx) != osxsave|avx {
+		return false
+	}
+	// the OS must save the XMM and YMM registers
+	if xcr0, _ := xgetbv(); xcr0&6 != 6 {
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
+
+//go:noescape
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+//go:noescape
+func xgetbv() (eax, edx uint32)
+
+// The assembly functions scan only the whole blocks of 16 bytes ( SSE2 ) or 32 bytes ( AVX2 ) given to them,
+// and the rest is scanned by the generic implementations.
+
+//go:noescape
+func indexEscapeSSE2(s string, html bool) int
+
+//go:noescape
+func indexEscapeAVX2(s string, html bool) int
+
+//go:noescape
+func indexStringEndSSE2(b []byte) int
+
+//go:noescape
+func indexStringEndAVX2(b []byte) int
+
+//go:noescape
+func asciiPrefixSSE2(b []byte) int
+
+//go:noescape
+func asciiPrefixAVX2(b []byte) int
+
+func indexEscape(s string, html bool) int {
+	var n, i int
+	if useAVX2 {
+		n = len(s) &^ 31
+		if n > 0 {
+			i = indexEscapeAVX2(s[:n], html)
+		}
+	} else {
+		n = len(s) &^ 15
+		if n > 0 {
+			i = indexEscapeSSE2(s[:n], html)
+		}
+	}
+	if n > 0 && i >= 0 {
+		return i
+	}
+	if i = indexEscapeGeneric(s[n:], html); i >= 0 {
+		return n + i
+	}
+	return -1
+}
+
+func indexStringEnd(b []byte) int {
+	var n, i int
+	if useAVX2 {
+		n = len(b) &^ 31
+		if n > 0 {
+			i = indexStringEndAVX2(b[:n])
+		}
+	} else {
+		n = len(b) &^ 15
+		if n > 0 {
+			i = indexStringEndSSE2(b[:n])
+		}
+	}
+	if n > 0 && i >= 0 {
+		return i
+	}
+	if i = indexStringEndGeneric(b[n:]); i >= 0 {
+		return n + i
+	}
+	return -1
+}
+
+func asciiPrefix(b []byte) int {
+	var n, i int
+	if useAVX2 {
+		n = len(b) &^ 31
+		if n > 0 {
+			i = asciiPrefixAVX2(b[:n])
+		}
+	} else {
+		n = len(b) &^ 15
+		if n > 0 {
+			i = asciiPrefixSSE2(b[:n])
+		}
+	}
+	if i < n {
+		return i
+	}
+	return n + asciiPrefixGeneric(b[n:])
+}
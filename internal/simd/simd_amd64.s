@@ -0,0 +1,286 @@
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// BROADCAST16 fills the 16 bytes of xmm with the byte c.
+#define BROADCAST16(c, xmm) \
+	MOVQ $(c * 0x0101010101010101), AX \
+	MOVQ AX, xmm                       \
+	PUNPCKLQDQ xmm, xmm
+
+// BROADCAST32 fills the 32 bytes of ymm with the byte c. xmm must be the lower half of ymm.
+#define BROADCAST32(c, xmm, ymm) \
+	MOVQ $c, AX        \
+	MOVQ AX, xmm       \
+	VPBROADCASTB xmm, ymm
+
+// func indexEscapeSSE2(s string, html bool) int
+TEXT ·indexEscapeSSE2(SB), NOSPLIT, $0-32
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), CX
+	MOVBQZX html+16(FP), DX
+	MOVQ SI, DI
+	LEAQ (SI)(CX*1), BX
+	BROADCAST16(0x1f, X8)
+	BROADCAST16(0x22, X9)
+	BROADCAST16(0x5c, X10)
+	BROADCAST16(0x3c, X11)
+	BROADCAST16(0x3e, X12)
+	BROADCAST16(0x26, X13)
+
+escape_sse2_loop:
+	CMPQ SI, BX
+	JAE  escape_sse2_notfound
+	MOVOU (SI), X0
+
+	// the bytes outside of ASCII have the most significant bit
+	PMOVMSKB X0, AX
+
+	// control characters: min(x, 0x1f) == x
+	MOVOU   X0, X1
+	PMINUB  X8, X1
+	PCMPEQB X0, X1
+
+	MOVOU   X0, X2
+	PCMPEQB X9, X2
+	POR     X2, X1
+	MOVOU   X0, X2
+	PCMPEQB X10, X2
+	POR     X2, X1
+
+	TESTQ DX, DX
+	JZ    escape_sse2_mask
+	MOVOU   X0, X2
+	PCMPEQB X11, X2
+	POR     X2, X1
+	MOVOU   X0, X2
+	PCMPEQB X12, X2
+	POR     X2, X1
+	MOVOU   X0, X2
+	PCMPEQB X13, X2
+	POR     X2, X1
+
+escape_sse2_mask:
+	PMOVMSKB X1, R8
+	ORQ      R8, AX
+	JNZ      escape_sse2_found
+	ADDQ     $16, SI
+	JMP      escape_sse2_loop
+
+escape_sse2_found:
+	BSFQ AX, AX
+	SUBQ DI, SI
+	ADDQ SI, AX
+	MOVQ AX, ret+24(FP)
+	RET
+
+escape_sse2_notfound:
+	MOVQ $-1, ret+24(FP)
+	RET
+
+// func indexEscapeAVX2(s string, html bool) int
+TEXT ·indexEscapeAVX2(SB), NOSPLIT, $0-32
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), CX
+	MOVBQZX html+16(FP), DX
+	MOVQ SI, DI
+	LEAQ (SI)(CX*1), BX
+	BROADCAST32(0x1f, X8, Y8)
+	BROADCAST32(0x22, X9, Y9)
+	BROADCAST32(0x5c, X10, Y10)
+	BROADCAST32(0x3c, X11, Y11)
+	BROADCAST32(0x3e, X12, Y12)
+	BROADCAST32(0x26, X13, Y13)
+
+escape_avx2_loop:
+	CMPQ SI, BX
+	JAE  escape_avx2_notfound
+	VMOVDQU (SI), Y0
+	VPMOVMSKB Y0, AX
+
+	VPMINUB  Y8, Y0, Y1
+	VPCMPEQB Y0, Y1, Y1
+	VPCMPEQB Y9, Y0, Y2
+	VPOR     Y2, Y1, Y1
+	VPCMPEQB Y10, Y0, Y2
+	VPOR     Y2, Y1, Y1
+
+	TESTQ DX, DX
+	JZ    escape_avx2_mask
+	VPCMPEQB Y11, Y0, Y2
+	VPOR     Y2, Y1, Y1
+	VPCMPEQB Y12, Y0, Y2
+	VPOR     Y2, Y1, Y1
+	VPCMPEQB Y13, Y0, Y2
+	VPOR     Y2, Y1, Y1
+
+escape_avx2_mask:
+	VPMOVMSKB Y1, R8
+	ORQ       R8, AX
+	JNZ       escape_avx2_found
+	ADDQ      $32, SI
+	JMP       escape_avx2_loop
+
+escape_avx2_found:
+	VZEROUPPER
+	BSFQ AX, AX
+	SUBQ DI, SI
+	ADDQ SI, AX
+	MOVQ AX, ret+24(FP)
+	RET
+
+escape_avx2_notfound:
+	VZEROUPPER
+	MOVQ $-1, ret+24(FP)
+	RET
+
+// func indexStringEndSSE2(b []byte) int
+TEXT ·indexStringEndSSE2(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	MOVQ SI, DI
+	LEAQ (SI)(CX*1), BX
+	BROADCAST16(0x22, X9)
+	BROADCAST16(0x5c, X10)
+	PXOR X11, X11
+
+end_sse2_loop:
+	CMPQ SI, BX
+	JAE  end_sse2_notfound
+	MOVOU   (SI), X0
+	MOVOU   X0, X1
+	PCMPEQB X9, X1
+	MOVOU   X0, X2
+	PCMPEQB X10, X2
+	POR     X2, X1
+	PCMPEQB X11, X0
+	POR     X0, X1
+	PMOVMSKB X1, AX
+	TESTQ    AX, AX
+	JNZ      end_sse2_found
+	ADDQ     $16, SI
+	JMP      end_sse2_loop
+
+end_sse2_found:
+	BSFQ AX, AX
+	SUBQ DI, SI
+	ADDQ SI, AX
+	MOVQ AX, ret+24(FP)
+	RET
+
+end_sse2_notfound:
+	MOVQ $-1, ret+24(FP)
+	RET
+
+// func indexStringEndAVX2(b []byte) int
+TEXT ·indexStringEndAVX2(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	MOVQ SI, DI
+	LEAQ (SI)(CX*1), BX
+	BROADCAST32(0x22, X9, Y9)
+	BROADCAST32(0x5c, X10, Y10)
+	VPXOR Y11, Y11, Y11
+
+end_avx2_loop:
+	CMPQ SI, BX
+	JAE  end_avx2_notfound
+	VMOVDQU  (SI), Y0
+	VPCMPEQB Y9, Y0, Y1
+	VPCMPEQB Y10, Y0, Y2
+	VPOR     Y2, Y1, Y1
+	VPCMPEQB Y11, Y0, Y2
+	VPOR     Y2, Y1, Y1
+	VPMOVMSKB Y1, AX
+	TESTQ     AX, AX
+	JNZ       end_avx2_found
+	ADDQ      $32, SI
+	JMP       end_avx2_loop
+
+end_avx2_found:
+	VZEROUPPER
+	BSFQ AX, AX
+	SUBQ DI, SI
+	ADDQ SI, AX
+	MOVQ AX, ret+24(FP)
+	RET
+
+end_avx2_notfound:
+	VZEROUPPER
+	MOVQ $-1, ret+24(FP)
+	RET
+
+// func asciiPrefixSSE2(b []byte) int
+TEXT ·asciiPrefixSSE2(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	MOVQ SI, DI
+	LEAQ (SI)(CX*1), BX
+
+ascii_sse2_loop:
+	CMPQ SI, BX
+	JAE  ascii_sse2_end
+	MOVOU    (SI), X0
+	PMOVMSKB X0, AX
+	TESTQ    AX, AX
+	JNZ      ascii_sse2_found
+	ADDQ     $16, SI
+	JMP      ascii_sse2_loop
+
+ascii_sse2_found:
+	BSFQ AX, AX
+	SUBQ DI, SI
+	ADDQ SI, AX
+	MOVQ AX, ret+24(FP)
+	RET
+
+ascii_sse2_end:
+	MOVQ CX, ret+24(FP)
+	RET
+
+// func asciiPrefixAVX2(b []byte) int
+TEXT ·asciiPrefixAVX2(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	MOVQ SI, DI
+	LEAQ (SI)(CX*1), BX
+
+ascii_avx2_loop:
+	CMPQ SI, BX
+	JAE  ascii_avx2_end
+	VMOVDQU   (SI), Y0
+	VPMOVMSKB Y0, AX
+	TESTQ     AX, AX
+	JNZ       ascii_avx2_found
+	ADDQ      $32, SI
+	JMP       ascii_avx2_loop
+
+ascii_avx2_found:
+	VZEROUPPER
+	BSFQ AX, AX
+	SUBQ DI, SI
+	ADDQ SI, AX
+	MOVQ AX, ret+24(FP)
+	RET
+
+ascii_avx2_end:
+	VZEROUPPER
+	MOVQ CX, ret+24(FP)
+	RET
@@ -0,0 +1,19 @@
+//go:build !amd64
+// +build !amd64
+
+package simd
+
+// useAVX2 is only used on amd64, and is defined to share the tests.
+var useAVX2 = false
+
+func indexEscape(s string, html bool) int {
+	return indexEscapeGeneric(s, html)
+}
+
+func indexStringEnd(b []byte) int {
+	return indexStringEndGeneric(b)
+}
+
+func asciiPrefix(b []byte) int {
+	return asciiPrefixGeneric(b)
+}
@@ -0,0 +1,75 @@
+package simd
+
+import (
+	"math/rand"
+	"testing"
+	"unicode/utf8"
+)
+
+func randomInputs() [][]byte {
+	r := rand.New(rand.NewSource(1))
+	alphabet := []byte("abcdefgh01234 \"\\<>&\x00\x01\x1f\x7f\x80\xe3\x81\x82\xff")
+	var inputs [][]byte
+	for n := 0; n < 200; n++ {
+		for k := 0; k < 20; k++ {
+			b := make([]byte, n)
+			for i := range b {
+				b[i] = 'a'
+			}
+			// a few special bytes at random positions, so that the blocks without them are also scanned
+			for j := r.Intn(3); j > 0 && n > 0; j-- {
+				b[r.Intn(n)] = alphabet[r.Intn(len(alphabet))]
+			}
+			inputs = append(inputs, b)
+		}
+	}
+	inputs = append(inputs, []byte("あいうえお, こんにちは世界, 0123456789abcdef0123456789abcdef"))
+	return inputs
+}
+
+// withEachImpl runs f with AVX2 and without AVX2 if the CPU supports it.
+func withEachImpl(t *testing.T, f func(t *testing.T)) {
+	supported := useAVX2
+	defer func() { useAVX2 = supported }()
+	useAVX2 = false
+	t.Run("SSE2", f)
+	if supported {
+		useAVX2 = true
+		t.Run("AVX2", f)
+	}
+}
+
+func TestIndexEscape(t *testing.T) {
+	withEachImpl(t, func(t *testing.T) {
+		for _, b := range randomInputs() {
+			for _, html := range []bool{false, true} {
+				if expected, got := indexEscapeGeneric(string(b), html), IndexEscape(string(b), html); expected != got {
+					t.Fatalf("IndexEscape(%q, %v): expected %d but got %d", b, html, expected, got)
+				}
+			}
+		}
+	})
+}
+
+func TestIndexStringEnd(t *testing.T) {
+	withEachImpl(t, func(t *testing.T) {
+		for _, b := range randomInputs() {
+			if expected, got := indexStringEndGeneric(b), IndexStringEnd(b); expected != got {
+				t.Fatalf("IndexStringEnd(%q): expected %d but got %d", b, expected, got)
+			}
+		}
+	})
+}
+
+func TestValidUTF8(t *testing.T) {
+	withEachImpl(t, func(t *testing.T) {
+		for _, b := range randomInputs() {
+			if expected, got := asciiPrefixGeneric(b), asciiPrefix(b); expected != got {
+				t.Fatalf("asciiPrefix(%q): expected %d but got %d", b, expected, got)
+			}
+			if expected, got := utf8.Valid(b), ValidUTF8(b); expected != got {
+				t.Fatalf("ValidUTF8(%q): expected %v but got %v", b, expected, got)
+			}
+		}
+	})
+}
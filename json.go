@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 
 	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
 )
 
 // Marshaler is the interface implemented by types that
@@ -55,6 +56,9 @@ type UnmarshalerContext interface {
 // Boolean values encode as JSON booleans.
 //
 // Floating point, integer, and Number values encode as JSON numbers.
+// *big.Int, *big.Float and *big.Rat values also encode as JSON numbers, without losing precision.
+// A *big.Rat that has no finite decimal representation (e.g. 1/3) is rounded
+// to 20 digits after the decimal point unless the precision is specified.
 //
 // String values encode as JSON strings coerced to valid UTF-8,
 // replacing invalid bytes with the Unicode replacement rune.
@@ -115,6 +119,11 @@ type UnmarshalerContext interface {
 //
 //    Int64String int64 `json:",string"`
 //
+// The "precision=N" option specifies the number of digits after the decimal point
+// used when encoding a floating point, *big.Float or *big.Rat field:
+//
+//    Price float64 `json:"price,precision=2"`
+//
 // The key name will be used if it's a non-empty string consisting of
 // only Unicode letters, digits, and ASCII punctuation except quotation
 // marks, backslash, and comma.
@@ -198,6 +207,18 @@ func MarshalIndentWithOption(v interface{}, prefix, indent string, optFuncs ...E
 	return marshalIndent(v, prefix, indent, optFuncs...)
 }
 
+// MarshalCanonical returns the canonical JSON encoding of v defined by RFC 8785 ( JSON Canonicalization Scheme ).
+// The output is byte-exact with the other implementations of JCS, so it can be used for hashing and signing.
+// Object keys including struct field names are sorted by their UTF-16 code units.
+// See Canonicalize for the details of the canonical form.
+func MarshalCanonical(v interface{}) ([]byte, error) {
+	b, err := marshal(v, UnorderedMap(), StringEscape(StringEscapeMinimal))
+	if err != nil {
+		return nil, err
+	}
+	return encoder.Canonicalize(nil, b)
+}
+
 // Unmarshal parses the JSON-encoded data and stores the result
 // in the value pointed to by v. If v is nil or not a pointer,
 // Unmarshal returns an InvalidUnmarshalError.
@@ -319,6 +340,15 @@ func Compact(dst *bytes.Buffer, src []byte) error {
 	return encoder.Compact(dst, src, false)
 }
 
+// Canonicalize returns the canonical form of the JSON-encoded src defined by RFC 8785 ( JSON Canonicalization Scheme ).
+// Object members are sorted by the UTF-16 code units of their keys, numbers are formatted
+// as ECMAScript does ( e.g. 1.0 becomes 1 and 1e-7 stays 1e-7 ), strings are minimally escaped
+// and insignificant space characters are elided.
+// Duplicate object keys, invalid UTF-8 and lone surrogates are reported as errors.
+func Canonicalize(src []byte) ([]byte, error) {
+	return encoder.Canonicalize(nil, src)
+}
+
 // Indent appends to dst an indented form of the JSON-encoded src.
 // Each element in a JSON object or array begins on a new,
 // indented line beginning with prefix followed by one or more
@@ -364,3 +394,11 @@ func Valid(data []byte) bool {
 	}
 	return decoder.InputOffset() >= int64(len(data))
 }
+
+// OrderedObject is a JSON object that keeps its keys in insertion order.
+// It is produced instead of map[string]interface{} when decoding into interface{} with DecodeUseOrderedObject,
+// and is encoded as a JSON object in the order of its entries.
+type OrderedObject = runtime.OrderedObject
+
+// OrderedObjectEntry is a key/value pair of OrderedObject.
+type OrderedObjectEntry = runtime.OrderedObjectEntry
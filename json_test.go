@@ -3,10 +3,13 @@ package json_test
 import (
 	"bytes"
 	stdjson "encoding/json"
+	"fmt"
 	"math"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -142,6 +145,65 @@ func TestCompactSeparators(t *testing.T) {
 	}
 }
 
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		in, canonical string
+	}{
+		// RFC 8785 section 3.2.2
+		{
+			"{\n  \"numbers\": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],\n" +
+				"  \"string\": \"\\u20ac$\\u000F\\u000aA'\\u0042\\u0022\\u005c\\\\\\\"\\/\",\n" +
+				"  \"literals\": [null, true, false]\n}",
+			`{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		// RFC 8785 section 3.2.3
+		{
+			`{"\u20ac":"Euro Sign","\r":"Carriage Return","\ufb33":"Hebrew Letter Dalet With Dagesh","1":"One",` +
+				`"\ud83d\ude00":"Emoji: Grinning Face","\u0080":"Control","\u00f6":"Latin Small Letter O With Diaeresis"}`,
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\"," +
+				"\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{`[-0, 1e21, 999999999999999900000, 0.000001, 9.999999999999997e-7, 5e-324]`, `[0,1e+21,999999999999999900000,0.000001,9.999999999999997e-7,5e-324]`},
+		{"\"\\b\\f\\u001f\\u2028<>\"", "\"\\b\\f\\u001f\u2028<>\""},
+		{` { "b" : { "d" : [ ], "c" : { } }, "a" : "" } `, `{"a":"","b":{"c":{},"d":[]}}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Canonicalize([]byte(tt.in))
+		if err != nil {
+			t.Errorf("Canonicalize(%q): %v", tt.in, err)
+		} else if string(got) != tt.canonical {
+			t.Errorf("Canonicalize(%q) = %q, want %q", tt.in, got, tt.canonical)
+		}
+	}
+	for _, src := range []string{`{"a":1,"a":2}`, `"\ud800"`, "\"\xff\"", `[1e400]`, `{"a":1}}`, ``} {
+		if _, err := json.Canonicalize([]byte(src)); err == nil {
+			t.Errorf("Canonicalize(%q): expected error", src)
+		}
+	}
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	type T struct {
+		Z     string      `json:"z"`
+		A     float64     `json:"a"`
+		Inner interface{} `json:"inner"`
+		Raw   json.RawMessage
+	}
+	got, err := json.MarshalCanonical(T{
+		Z:     "<\u2028>",
+		A:     1e-7,
+		Inner: []interface{}{float32(0.1), 100, json.Number("1.50")},
+		Raw:   json.RawMessage(`{ "y": 1.0, "x": [ ] }`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "{\"Raw\":{\"x\":[],\"y\":1},\"a\":1e-7,\"inner\":[0.1,100,1.5],\"z\":\"<\u2028>\"}"
+	if string(got) != expected {
+		t.Errorf("MarshalCanonical = %q, want %q", got, expected)
+	}
+}
+
 func TestIndent(t *testing.T) {
 	var buf bytes.Buffer
 	for _, tt := range examples {
@@ -270,6 +332,245 @@ func TestIndentErrors(t *testing.T) {
 	}
 }
 
+type precompileT struct {
+	A int               `json:"a"`
+	B []string          `json:"b"`
+	C *precompileInnerT `json:"c"`
+}
+
+type precompileInnerT struct {
+	D float64 `json:"d"`
+}
+
+func TestPrecompile(t *testing.T) {
+	contains := func(types []reflect.Type, typ reflect.Type) bool {
+		for _, v := range types {
+			if v == typ {
+				return true
+			}
+		}
+		return false
+	}
+	t.Run("cached", func(t *testing.T) {
+		if err := json.Precompile(precompileT{}, reflect.TypeOf(float32(0))); err != nil {
+			t.Fatal(err)
+		}
+		encode, decode := json.CachedTypes()
+		for _, typ := range []reflect.Type{reflect.TypeOf(precompileT{}), reflect.TypeOf(&precompileT{}), reflect.TypeOf(float32(0))} {
+			if !contains(encode, typ) {
+				t.Errorf("%s is not in the encode types", typ)
+			}
+		}
+		for _, typ := range []reflect.Type{reflect.TypeOf(precompileT{}), reflect.TypeOf(float32(0))} {
+			if !contains(decode, typ) {
+				t.Errorf("%s is not in the decode types", typ)
+			}
+		}
+		var v precompileT
+		if err := json.Unmarshal([]byte(`{"a":1,"b":["x"],"c":{"d":1.5}}`), &v); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(&v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != `{"a":1,"b":["x"],"c":{"d":1.5}}` {
+			t.Errorf("unexpected result: %s", got)
+		}
+	})
+	t.Run("pointer", func(t *testing.T) {
+		type T struct{ A uint8 }
+		if err := json.Precompile(&T{}); err != nil {
+			t.Fatal(err)
+		}
+		encode, decode := json.CachedTypes()
+		if !contains(encode, reflect.TypeOf(T{})) || !contains(encode, reflect.TypeOf(&T{})) {
+			t.Error("T and *T are not in the encode types")
+		}
+		if !contains(decode, reflect.TypeOf(T{})) {
+			t.Error("T is not in the decode types")
+		}
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		type T struct{ C complex128 }
+		err := json.Precompile(precompileInnerT{}, T{})
+		if _, ok := err.(*json.UnsupportedTypeError); !ok {
+			t.Errorf("expected *json.UnsupportedTypeError but got %T: %v", err, err)
+		}
+		if err := json.Precompile(nil); err == nil {
+			t.Error("expected error for nil")
+		}
+	})
+}
+
+func TestDynamicTypeCache(t *testing.T) {
+	newType := func(i int) reflect.Type {
+		return reflect.StructOf([]reflect.StructField{
+			{Name: "A", Type: reflect.TypeOf(0), Tag: reflect.StructTag(fmt.Sprintf(`json:"a%d"`, i))},
+		})
+	}
+	countTypes := func(types []reflect.Type) int {
+		n := 0
+		for _, typ := range types {
+			if typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+			if typ.Kind() == reflect.Struct && typ.PkgPath() == "" && strings.HasPrefix(typ.String(), "struct { A int \"json:\\\"a") {
+				n++
+			}
+		}
+		return n
+	}
+	roundTrip := func(t *testing.T, i int) {
+		v := reflect.New(newType(i))
+		if err := json.Unmarshal([]byte(fmt.Sprintf(`{"a%d":%d}`, i, i)), v.Interface()); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(v.Interface())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := fmt.Sprintf(`{"a%d":%d}`, i, i); string(got) != expected {
+			t.Fatalf("expected %s but got %s", expected, got)
+		}
+	}
+	t.Run("unbounded", func(t *testing.T) {
+		for i := 0; i < 500; i++ {
+			roundTrip(t, i)
+		}
+		for i := 0; i < 500; i++ {
+			roundTrip(t, i)
+		}
+		encode, decode := json.CachedTypes()
+		if n := countTypes(encode); n < 500 {
+			t.Errorf("expected at least 500 encode types but got %d", n)
+		}
+		if n := countTypes(decode); n < 500 {
+			t.Errorf("expected at least 500 decode types but got %d", n)
+		}
+	})
+	t.Run("bounded", func(t *testing.T) {
+		json.SetCacheLimit(64)
+		defer json.SetCacheLimit(0)
+		for i := 500; i < 1500; i++ {
+			roundTrip(t, i)
+		}
+		encode, decode := json.CachedTypes()
+		if n := countTypes(encode); n > 128 {
+			t.Errorf("expected at most 128 encode types but got %d", n)
+		}
+		if n := countTypes(decode); n > 128 {
+			t.Errorf("expected at most 128 decode types but got %d", n)
+		}
+	})
+}
+
+type schemaNode struct {
+	Name     string        `json:"name"`
+	Children []*schemaNode `json:"children,omitempty"`
+}
+
+type schemaBase struct {
+	ID   int64 `json:"id,string"`
+	Name string
+}
+
+type schemaExtra struct {
+	Note string `json:"note"`
+}
+
+type schemaConflictA struct {
+	Value int
+}
+
+type schemaConflictB struct {
+	Value int
+}
+
+type schemaT struct {
+	schemaBase
+	*schemaExtra
+	schemaConflictA
+	schemaConflictB
+	Level int8        `json:"level,omitempty"`
+	Items [2]bool     `json:"items,omitempty"`
+	Data  []byte      `json:"data,format=hex"`
+	Root  *schemaNode `json:"root"`
+	Any   interface{} `json:"any"`
+	Name  string      `json:"name"`
+	Num   json.Number `json:"num"`
+	Quote json.Number `json:"quote,string"`
+	Dates []time.Time `json:"dates,omitempty,format=2006-01-02"`
+}
+
+func TestSchema(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		got, err := json.Schema(schemaT{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$ref": "#/$defs/schemaT",
+  "$defs": {
+    "schemaT": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string"},
+        "Name": {"type": "string"},
+        "note": {"type": "string"},
+        "level": {"type": "integer", "minimum": -128, "maximum": 127},
+        "items": {"type": "array", "items": {"type": "boolean"}, "minItems": 2, "maxItems": 2},
+        "data": {"type": ["string", "null"], "contentEncoding": "base16"},
+        "root": {"anyOf": [{"$ref": "#/$defs/schemaNode"}, {"type": "null"}]},
+        "any": {},
+        "name": {"type": "string"},
+        "num": {"type": "number"},
+        "quote": {"type": "string"},
+        "dates": {"type": ["array", "null"], "items": {"type": "string", "format": "date"}}
+      },
+      "required": ["id", "Name", "items", "data", "root", "any", "name", "num", "quote"],
+      "additionalProperties": false
+    },
+    "schemaNode": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "children": {"type": ["array", "null"], "items": {"anyOf": [{"$ref": "#/$defs/schemaNode"}, {"type": "null"}]}}
+      },
+      "required": ["name"],
+      "additionalProperties": false
+    }
+  }
+}`
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(expected)); err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != buf.String() {
+			t.Errorf("expected %s but got %s", buf.String(), got)
+		}
+	})
+	t.Run("pointer", func(t *testing.T) {
+		got, err := json.Schema(reflect.TypeOf((*int)(nil)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["integer","null"]}`
+		if string(got) != expected {
+			t.Errorf("expected %s but got %s", expected, got)
+		}
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := json.Schema(func() {}); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := json.Schema(nil); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
 func diff(t *testing.T, a, b []byte) {
 	t.Helper()
 	for i := 0; ; i++ {
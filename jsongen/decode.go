@@ -0,0 +1,491 @@
+package jsongen
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/goccy/go-json"
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// Lexer reads the JSON passed to the generated UnmarshalJSON methods.
+//
+// The methods reading a value in place return false without moving the cursor if they cannot read it,
+// for example, if the value has another type, does not fit in the type or is null for a value that is not a pointer.
+// Then the generated code decodes the value by Decode, which reports the same error as Unmarshal does.
+type Lexer struct {
+	buf    []byte
+	cursor int
+}
+
+// NewLexer returns a Lexer reading a copy of data.
+// As the decoder does for the copy of the input, the strings without escape sequences are decoded in place of the copy.
+func NewLexer(data []byte) Lexer {
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	return Lexer{buf: buf}
+}
+
+func (l *Lexer) skipWhiteSpace() int {
+	for l.cursor < len(l.buf) {
+		switch l.buf[l.cursor] {
+		case ' ', '\t', '\n', '\r':
+			l.cursor++
+			continue
+		}
+		break
+	}
+	return l.cursor
+}
+
+func (l *Lexer) peek(c byte) bool {
+	cursor := l.skipWhiteSpace()
+	return cursor < len(l.buf) && l.buf[cursor] == c
+}
+
+func (l *Lexer) literal(literal string) bool {
+	cursor := l.skipWhiteSpace()
+	if end := cursor + len(literal); end <= len(l.buf) && string(l.buf[cursor:end]) == literal {
+		l.cursor = end
+		return true
+	}
+	return false
+}
+
+// Null reads null and reports whether the value is null.
+func (l *Lexer) Null() bool {
+	return l.literal("null")
+}
+
+// BeginObject reads the opening brace of an object, and reports whether the object has members.
+func (l *Lexer) BeginObject() (bool, error) {
+	cursor := l.skipWhiteSpace()
+	if cursor == len(l.buf) {
+		return false, errors.ErrUnexpectedEndOfJSON("object", int64(cursor))
+	}
+	if l.buf[cursor] != '{' {
+		return false, errors.ErrInvalidBeginningOfValue(l.buf[cursor], int64(cursor))
+	}
+	l.cursor++
+	if l.peek('}') {
+		l.cursor++
+		return false, nil
+	}
+	return true, nil
+}
+
+// Key reads the key of a member and the colon after it, and returns the key without escape sequences.
+func (l *Lexer) Key() ([]byte, error) {
+	cursor := l.skipWhiteSpace()
+	if cursor == len(l.buf) || l.buf[cursor] != '"' {
+		return nil, errors.ErrExpected("object key", int64(cursor))
+	}
+	key, ok, err := l.readString()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.ErrUnexpectedEndOfJSON("string", int64(cursor))
+	}
+	if !l.peek(':') {
+		return nil, errors.ErrExpected("colon after object key", int64(l.cursor))
+	}
+	l.cursor++
+	return key, nil
+}
+
+// NextMember reads the comma or the closing brace after a member, and reports whether another member follows.
+func (l *Lexer) NextMember() (bool, error) {
+	cursor := l.skipWhiteSpace()
+	if cursor == len(l.buf) {
+		return false, errors.ErrUnexpectedEndOfJSON("object", int64(cursor))
+	}
+	switch l.buf[cursor] {
+	case ',':
+		l.cursor++
+		return true, nil
+	case '}':
+		l.cursor++
+		return false, nil
+	}
+	return false, errors.ErrExpected("comma after object element", int64(cursor))
+}
+
+// BeginArray reads the opening bracket of an array, and reports whether the array has elements.
+// It returns false for ok if the value is not an array.
+func (l *Lexer) BeginArray() (more bool, ok bool) {
+	if !l.peek('[') {
+		return false, false
+	}
+	l.cursor++
+	if l.peek(']') {
+		l.cursor++
+		return false, true
+	}
+	return true, true
+}
+
+// NextElement reads the comma or the closing bracket after an element, and reports whether another element follows.
+func (l *Lexer) NextElement() (bool, error) {
+	cursor := l.skipWhiteSpace()
+	if cursor == len(l.buf) {
+		return false, errors.ErrUnexpectedEndOfJSON("slice", int64(cursor))
+	}
+	switch l.buf[cursor] {
+	case ',':
+		l.cursor++
+		return true, nil
+	case ']':
+		l.cursor++
+		return false, nil
+	}
+	return false, errors.ErrInvalidCharacter(l.buf[cursor], "slice", int64(cursor))
+}
+
+// Skip reads the value of an unknown key.
+func (l *Lexer) Skip() error {
+	_, err := l.raw()
+	return err
+}
+
+// End reports an error if data has other than white spaces after the value.
+func (l *Lexer) End() error {
+	if cursor := l.skipWhiteSpace(); cursor < len(l.buf) {
+		return errors.ErrSyntax("invalid character '"+string(l.buf[cursor])+"' after top-level value", int64(cursor+1))
+	}
+	return nil
+}
+
+func (l *Lexer) raw() ([]byte, error) {
+	start := l.skipWhiteSpace()
+	if start == len(l.buf) {
+		return nil, errors.ErrUnexpectedEndOfJSON("value", int64(start))
+	}
+	end, ok := decoder.ScanValue(l.buf, start)
+	if !ok {
+		return nil, errors.ErrSyntax("invalid value", int64(start))
+	}
+	l.cursor = end
+	return l.buf[start:end], nil
+}
+
+// readString reads the string literal at the cursor, and returns it without the quotes and escape sequences.
+// It returns false if the literal is invalid.
+func (l *Lexer) readString() ([]byte, bool, error) {
+	start := l.cursor
+	i := start + 1
+	for ; i < len(l.buf); i++ {
+		c := l.buf[i]
+		if c == '"' {
+			l.cursor = i + 1
+			return l.buf[start+1 : i], true, nil
+		}
+		if c == '\\' || c < 0x20 {
+			break
+		}
+	}
+	// scan the rest from the escape sequence as the string literal starting before it
+	end, ok := decoder.ScanString(l.buf, i-1)
+	if !ok {
+		return nil, false, nil
+	}
+	s, err := decoder.UnescapeString(l.buf[start+1:end-1], int64(start+1))
+	if err != nil {
+		return nil, false, err
+	}
+	l.cursor = end
+	return s, true, nil
+}
+
+// str returns the string at the cursor without moving the cursor.
+func (l *Lexer) str() ([]byte, int, bool) {
+	start := l.skipWhiteSpace()
+	if start == len(l.buf) || l.buf[start] != '"' {
+		return nil, 0, false
+	}
+	s, ok, err := l.readString()
+	end := l.cursor
+	l.cursor = start
+	if err != nil || !ok {
+		return nil, 0, false
+	}
+	return s, end, true
+}
+
+// number returns the number at the cursor without moving the cursor.
+func (l *Lexer) number() ([]byte, int, bool) {
+	start := l.skipWhiteSpace()
+	end, ok := decoder.ScanNumber(l.buf, start)
+	if !ok {
+		return nil, 0, false
+	}
+	return l.buf[start:end], end, true
+}
+
+// quoted returns the value in the string at the cursor, for the fields with the string option.
+func (l *Lexer) quoted() (Lexer, int, bool) {
+	s, end, ok := l.str()
+	if !ok {
+		return Lexer{}, 0, false
+	}
+	return Lexer{buf: s}, end, true
+}
+
+// String reads a string.
+func (l *Lexer) String() (string, bool) {
+	s, end, ok := l.str()
+	if !ok {
+		return "", false
+	}
+	l.cursor = end
+	return *(*string)(unsafe.Pointer(&s)), true
+}
+
+// Bool reads true or false.
+func (l *Lexer) Bool() (bool, bool) {
+	switch {
+	case l.literal("true"):
+		return true, true
+	case l.literal("false"):
+		return false, true
+	}
+	return false, false
+}
+
+// Int reads an integer that fits in the signed integer of bitSize. The bit size 0 means the size of int.
+func (l *Lexer) Int(bitSize int) (int64, bool) {
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	}
+	num, end, ok := l.number()
+	if !ok || !isInteger(num) {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(string(num), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	// the decoder rejects the minimum value of the integers smaller than int64
+	if bitSize < 64 && (v <= -1<<uint(bitSize-1) || 1<<uint(bitSize-1) <= v) {
+		return 0, false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// Uint reads a non-negative integer that fits in the unsigned integer of bitSize. The bit size 0 means the size of uint.
+func (l *Lexer) Uint(bitSize int) (uint64, bool) {
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	}
+	num, end, ok := l.number()
+	if !ok || !isInteger(num) || num[0] == '-' {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(num), 10, 64)
+	if err != nil || (bitSize < 64 && 1<<uint(bitSize) <= v) {
+		return 0, false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// Float reads a number. The value is parsed as float64 as the decoder does for float32 too.
+func (l *Lexer) Float() (float64, bool) {
+	num, end, ok := l.number()
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(string(num), 64)
+	if err != nil {
+		return 0, false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// Bytes reads a string encoded by the standard base64 encoding.
+func (l *Lexer) Bytes() ([]byte, bool) {
+	s, end, ok := l.str()
+	if !ok {
+		return nil, false
+	}
+	b := make([]byte, base64.StdEncoding.DecodedLen(len(s)))
+	n, err := base64.StdEncoding.Decode(b, s)
+	if err != nil {
+		return nil, false
+	}
+	l.cursor = end
+	return b[:n], true
+}
+
+// Time reads a time in format as the decoder does for the format option of the tag.
+// The Unix time formats are read from a number, and the layouts are read from a string.
+// If format is empty, the time is read in RFC 3339 format as time.Time.UnmarshalJSON does.
+func (l *Lexer) Time(format string) (time.Time, bool) {
+	if unit, ok := runtime.UnixTimeUnit(format); ok {
+		num, end, ok := l.number()
+		if !ok {
+			return time.Time{}, false
+		}
+		t, ok := decoder.ParseUnixTime(num, unit)
+		if !ok {
+			return time.Time{}, false
+		}
+		l.cursor = end
+		return t, true
+	}
+	s, end, ok := l.str()
+	if !ok {
+		return time.Time{}, false
+	}
+	var (
+		t   time.Time
+		err error
+	)
+	if format == "" {
+		err = t.UnmarshalText(s)
+	} else {
+		t, err = time.Parse(format, string(s))
+	}
+	if err != nil {
+		return time.Time{}, false
+	}
+	l.cursor = end
+	return t, true
+}
+
+// QuotedString reads a string in a string, for the string fields with the string option.
+func (l *Lexer) QuotedString() (string, bool) {
+	q, end, ok := l.quoted()
+	if !ok {
+		return "", false
+	}
+	v, ok := q.String()
+	if !ok || q.End() != nil {
+		return "", false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// QuotedBool reads true or false in a string, for the bool fields with the string option.
+func (l *Lexer) QuotedBool() (bool, bool) {
+	q, end, ok := l.quoted()
+	if !ok {
+		return false, false
+	}
+	v, ok := q.Bool()
+	if !ok || q.End() != nil {
+		return false, false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// QuotedInt reads an integer in a string, for the integer fields with the string option.
+func (l *Lexer) QuotedInt(bitSize int) (int64, bool) {
+	q, end, ok := l.quoted()
+	if !ok {
+		return 0, false
+	}
+	v, ok := q.Int(bitSize)
+	if !ok || q.End() != nil {
+		return 0, false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// QuotedUint reads a non-negative integer in a string, for the unsigned integer fields with the string option.
+func (l *Lexer) QuotedUint(bitSize int) (uint64, bool) {
+	q, end, ok := l.quoted()
+	if !ok {
+		return 0, false
+	}
+	v, ok := q.Uint(bitSize)
+	if !ok || q.End() != nil {
+		return 0, false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// QuotedFloat reads a number in a string, for the float fields with the string option.
+func (l *Lexer) QuotedFloat() (float64, bool) {
+	q, end, ok := l.quoted()
+	if !ok {
+		return 0, false
+	}
+	v, ok := q.Float()
+	if !ok || q.End() != nil {
+		return 0, false
+	}
+	l.cursor = end
+	return v, true
+}
+
+// Decode reads a value and decodes it into the value pointed by v by the decoder.
+// If tag is not empty, the value is decoded as the value of a struct field with tag, for the string and format options.
+// The struct and the field names are set to *json.UnmarshalTypeError as the decoder does for the struct fields.
+func (l *Lexer) Decode(v interface{}, tag, structName, fieldName string) error {
+	value, err := l.raw()
+	if err != nil {
+		return err
+	}
+	if tag == "" {
+		// value is a part of the copy of the input, which is never modified
+		return annotateError(json.UnmarshalWithOption(value, v, json.DecodeZeroCopy()), structName, fieldName)
+	}
+	rv := reflect.ValueOf(v).Elem()
+	w := reflect.New(wrapperType(rv.Type(), tag))
+	w.Elem().Field(0).Set(rv)
+	data := make([]byte, 0, len(value)+6)
+	data = append(data, `{"v":`...)
+	data = append(append(data, value...), '}')
+	if err := json.Unmarshal(data, w.Interface()); err != nil {
+		return annotateError(err, structName, fieldName)
+	}
+	rv.Set(w.Elem().Field(0))
+	return nil
+}
+
+// ToLowerASCII returns key whose upper case ASCII letters are converted to lower case, as the decoder matches the keys.
+func ToLowerASCII(key []byte) []byte {
+	for i, c := range key {
+		if 'A' <= c && c <= 'Z' {
+			lower := make([]byte, len(key))
+			copy(lower, key[:i])
+			for j := i; j < len(key); j++ {
+				c := key[j]
+				if 'A' <= c && c <= 'Z' {
+					c += 'a' - 'A'
+				}
+				lower[j] = c
+			}
+			return lower
+		}
+	}
+	return key
+}
+
+func isInteger(num []byte) bool {
+	digits := num
+	if len(digits) > 0 && digits[0] == '-' {
+		digits = digits[1:]
+	}
+	if len(digits) == 0 || (digits[0] == '0' && len(digits) > 1) {
+		return false
+	}
+	for _, c := range digits {
+		if c < '0' || '9' < c {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+package jsongen
+
+import (
+	"encoding/base64"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/encoder/vm"
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+type buffer struct {
+	b []byte
+}
+
+var (
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			return &buffer{b: make([]byte, 0, 1024)}
+		},
+	}
+
+	// the generated methods do not escape HTML characters, since the encoder escapes them when it compacts the result of MarshalJSON.
+	encodeCtx  = &encoder.RuntimeContext{Option: &encoder.Option{}}
+	encodeCode = &encoder.Opcode{}
+	htmlCtx    = &encoder.RuntimeContext{Option: &encoder.Option{Flag: encoder.HTMLEscapeOption}}
+)
+
+// Marshal returns a copy of the bytes appended by appendFunc to a pooled buffer.
+func Marshal(appendFunc func(b []byte) ([]byte, error)) ([]byte, error) {
+	buf := bufferPool.Get().(*buffer)
+	b, err := appendFunc(buf.b[:0])
+	if err != nil {
+		bufferPool.Put(buf)
+		return nil, err
+	}
+	copied := make([]byte, len(b))
+	copy(copied, b)
+	buf.b = b
+	bufferPool.Put(buf)
+	return copied, nil
+}
+
+// AppendString appends s as a JSON string.
+func AppendString(b []byte, s string) []byte {
+	return encoder.AppendString(encodeCtx, b, s)
+}
+
+// AppendQuotedString appends the JSON string of s encoded as a JSON string, for the string fields with the string option.
+// The inner string is escaped for HTML as Marshal does.
+func AppendQuotedString(b []byte, s string) []byte {
+	buf := encoder.AppendString(htmlCtx, nil, s)
+	return encoder.AppendString(encodeCtx, b, string(buf))
+}
+
+// AppendInt appends v as a JSON number.
+func AppendInt(b []byte, v int64) []byte {
+	return strconv.AppendInt(b, v, 10)
+}
+
+// AppendUint appends v as a JSON number.
+func AppendUint(b []byte, v uint64) []byte {
+	return strconv.AppendUint(b, v, 10)
+}
+
+// AppendFloat32 appends v as a JSON number. It returns the error of Marshal for the non-finite values.
+func AppendFloat32(b []byte, v float32) ([]byte, error) {
+	if f := float64(v); math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, encoder.ErrUnsupportedFloat32(v)
+	}
+	return encoder.AppendFloat32(encodeCtx, encodeCode, b, v), nil
+}
+
+// AppendFloat64 appends v as a JSON number. It returns the error of Marshal for the non-finite values.
+func AppendFloat64(b []byte, v float64) ([]byte, error) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, encoder.ErrUnsupportedFloat(v)
+	}
+	return encoder.AppendFloat64(encodeCtx, encodeCode, b, v), nil
+}
+
+// AppendFloat32Precision appends v as a JSON number with prec digits after the decimal point, for the precision option of the tag.
+func AppendFloat32Precision(b []byte, v float32, prec int) ([]byte, error) {
+	if f := float64(v); math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, encoder.ErrUnsupportedFloat32(v)
+	}
+	code := encoder.Opcode{Flags: encoder.FloatPrecisionFlags, Precision: uint8(prec)}
+	return encoder.AppendFloat32(encodeCtx, &code, b, v), nil
+}
+
+// AppendFloat64Precision appends v as a JSON number with prec digits after the decimal point, for the precision option of the tag.
+func AppendFloat64Precision(b []byte, v float64, prec int) ([]byte, error) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, encoder.ErrUnsupportedFloat(v)
+	}
+	code := encoder.Opcode{Flags: encoder.FloatPrecisionFlags, Precision: uint8(prec)}
+	return encoder.AppendFloat64(encodeCtx, &code, b, v), nil
+}
+
+// AppendBool appends v as a JSON boolean.
+func AppendBool(b []byte, v bool) []byte {
+	return encoder.AppendBool(encodeCtx, b, v)
+}
+
+// AppendBytes appends v as a JSON string encoded by the standard base64 encoding.
+func AppendBytes(b []byte, v []byte) []byte {
+	b = append(b, '"')
+	n := base64.StdEncoding.EncodedLen(len(v))
+	if cap(b)-len(b) < n {
+		nb := make([]byte, len(b), 2*cap(b)+n)
+		copy(nb, b)
+		b = nb
+	}
+	base64.StdEncoding.Encode(b[len(b):len(b)+n], v)
+	b = b[:len(b)+n]
+	return append(b, '"')
+}
+
+// AppendTime appends t in format as the encoder does for the format option of the tag.
+// The Unix time formats are written as JSON number, and the layouts are written as JSON string.
+// If format is empty, t is written in RFC 3339 format as time.Time.MarshalJSON does.
+func AppendTime(b []byte, t time.Time, format string) ([]byte, error) {
+	if format == "" {
+		if y := t.Year(); y < 0 || y >= 10000 {
+			// use the error of MarshalJSON for the value that cannot be represented in RFC 3339 format.
+			if _, err := t.MarshalJSON(); err != nil {
+				return nil, &errors.MarshalerError{Type: reflect.TypeOf(t), Err: err}
+			}
+		}
+		b = append(b, '"')
+		b = t.AppendFormat(b, time.RFC3339Nano)
+		return append(b, '"'), nil
+	}
+	if unit, ok := runtime.UnixTimeUnit(format); ok {
+		return strconv.AppendInt(b, t.Unix()*int64(time.Second/unit)+int64(t.Nanosecond())/int64(unit), 10), nil
+	}
+	return AppendString(b, t.Format(format)), nil
+}
+
+// AppendValue appends the JSON encoding of v by the encoder.
+func AppendValue(b []byte, v interface{}) ([]byte, error) {
+	if v == nil {
+		return append(b, "null"...), nil
+	}
+	header := (*emptyInterface)(unsafe.Pointer(&v))
+	codeSet, err := encoder.CompileToGetCodeSet(uintptr(unsafe.Pointer(header.typ)))
+	if err != nil {
+		return nil, err
+	}
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = encoder.HTMLEscapeOption
+	ctx.Init(uintptr(header.ptr), codeSet.CodeLength)
+	buf, err := vm.Run(ctx, b, codeSet)
+	encoder.ReleaseRuntimeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// trim the comma written after the value
+	return buf[:len(buf)-1], nil
+}
+
+// AppendField appends the JSON encoding of the value pointed by v as the value of a struct field with tag by the encoder,
+// for the options of the tag applied to the values that are not written in place.
+func AppendField(b []byte, v interface{}, tag string) ([]byte, error) {
+	rv := reflect.ValueOf(v).Elem()
+	w := reflect.New(wrapperType(rv.Type(), tag)).Elem()
+	w.Field(0).Set(rv)
+	start := len(b)
+	b, err := AppendValue(b, w.Interface())
+	if err != nil {
+		return nil, err
+	}
+	// trim {"v": and }
+	return append(b[:start], b[start+5:len(b)-1]...), nil
+}
+
+// EndObject closes the object whose members are followed by a comma.
+func EndObject(b []byte) []byte {
+	if last := len(b) - 1; b[last] == ',' {
+		b[last] = '}'
+		return b
+	}
+	return append(b, '}')
+}
@@ -0,0 +1,70 @@
+// Package jsongen provides the functions called by the MarshalJSON and UnmarshalJSON methods generated by cmd/jsongen.
+//
+// The generated methods write and read the values in place by these functions, such as the basic kinds, time.Time,
+// pointers, slices and the structs of the same package. The other values, such as maps, interfaces, arrays and the types with
+// the marshaler methods, are passed to the runtime encoder and decoder by AppendValue and Lexer.Decode,
+// so that the generated methods behave as Marshal and Unmarshal do for the struct types without the methods.
+//
+// The generated methods do not take the options of MarshalWithOption and UnmarshalWithOption, since the signatures of
+// MarshalJSON and UnmarshalJSON have no room for them. The values are always encoded and decoded as Marshal and Unmarshal do
+// without options, and the values passed to the runtime are encoded and decoded without options too.
+package jsongen
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+type emptyInterface struct {
+	typ *runtime.Type
+	ptr unsafe.Pointer
+}
+
+var wrapperTypes sync.Map // wrapperKey => reflect.Type
+
+type wrapperKey struct {
+	typ reflect.Type
+	tag string
+}
+
+// wrapperType returns the struct type that has a field of typ with the options of tag,
+// so that the runtime applies the options of the struct tag to the value.
+func wrapperType(typ reflect.Type, tag string) reflect.Type {
+	key := wrapperKey{typ: typ, tag: tag}
+	if t, ok := wrapperTypes.Load(key); ok {
+		return t.(reflect.Type)
+	}
+	st := reflect.StructTag(tag)
+	wrapperTag := "v"
+	opts := strings.Split(st.Get("json"), ",")
+	for _, opt := range opts[1:] {
+		if opt != "omitempty" {
+			wrapperTag += "," + opt
+		}
+	}
+	t := reflect.StructOf([]reflect.StructField{
+		{Name: "V", Type: typ, Tag: reflect.StructTag("json:" + strconv.Quote(wrapperTag))},
+	})
+	wrapperTypes.Store(key, t)
+	return t
+}
+
+func annotateError(err error, structName, fieldName string) error {
+	if e, ok := err.(*errors.UnmarshalTypeError); ok {
+		e.Struct = structName
+		e.Field = fieldName
+	}
+	return err
+}
+
+// EmbeddedPointerError returns the error of the decoder for the fields of an embedded pointer to an unexported struct.
+func EmbeddedPointerError(typeName string) error {
+	return fmt.Errorf("json: cannot set embedded pointer to unexported struct: %s", typeName)
+}
@@ -0,0 +1,137 @@
+package json
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/encoder"
+)
+
+// A LineReader reads newline-delimited JSON ( NDJSON / JSON Lines ) from an input stream.
+// Each non-blank line must contain exactly one JSON value.
+type LineReader struct {
+	s             *decoder.Stream
+	line          int
+	err           error
+	skipMalformed bool
+}
+
+// NewLineReader returns a new reader that reads newline-delimited JSON from r.
+func NewLineReader(r io.Reader) *LineReader {
+	return &LineReader{s: decoder.NewStream(r)}
+}
+
+// SkipMalformedLines causes Next to skip the lines that cannot be decoded instead of returning an error.
+func (r *LineReader) SkipMalformedLines() {
+	r.skipMalformed = true
+}
+
+// Line returns the line number of the value read by the last call to Next.
+func (r *LineReader) Line() int {
+	return r.line
+}
+
+// Next reads the value in the next non-blank line and stores it in the value pointed to by v.
+// It returns io.EOF when there are no more lines.
+// An error decoding a line is returned as *LineError that has the line number.
+func (r *LineReader) Next(v interface{}) error {
+	return r.NextWithOption(v)
+}
+
+// NextWithOption call Next with DecodeOption.
+func (r *LineReader) NextWithOption(v interface{}, optFuncs ...DecodeOptionFunc) error {
+	header := (*emptyInterface)(unsafe.Pointer(&v))
+	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
+		return err
+	}
+	for {
+		if r.err != nil {
+			return r.err
+		}
+		line, err := r.s.ReadRecord('\n')
+		if err != nil {
+			r.err = err
+			if err != io.EOF || len(line) == 0 {
+				return err
+			}
+		}
+		r.line++
+		if len(bytes.Trim(line, " \t\r")) == 0 {
+			continue
+		}
+		if err := unmarshalRecord(line, v, optFuncs); err != nil {
+			if r.skipMalformed {
+				continue
+			}
+			return &LineError{Line: r.line, Err: err}
+		}
+		return nil
+	}
+}
+
+// unmarshalRecord decodes a record returned by decoder.Stream.ReadRecord in place.
+// The stream never overwrites the record, so the decoded strings point into it as those decoded by Decoder do.
+func unmarshalRecord(record []byte, v interface{}, optFuncs []DecodeOptionFunc) error {
+	return unmarshal(record, v, append(optFuncs[:len(optFuncs):len(optFuncs)], decodeRecordInPlace)...)
+}
+
+func decodeRecordInPlace(opt *DecodeOption) {
+	opt.Flags |= decoder.NoCopyOption | decoder.ZeroCopyOption
+}
+
+// A LineWriter writes newline-delimited JSON ( NDJSON / JSON Lines ) to an output stream.
+// Each value is written as compact JSON followed by a newline, so a LineWriter cannot indent.
+type LineWriter struct {
+	w                 io.Writer
+	enabledHTMLEscape bool
+}
+
+// NewLineWriter returns a new writer that writes newline-delimited JSON to w.
+func NewLineWriter(w io.Writer) *LineWriter {
+	return &LineWriter{w: w, enabledHTMLEscape: true}
+}
+
+// SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings.
+func (w *LineWriter) SetEscapeHTML(on bool) {
+	w.enabledHTMLEscape = on
+}
+
+// Encode writes the JSON encoding of v to the stream in a line.
+// If the encoding of v spans multiple lines, for example by a color scheme containing newlines, nothing is written and an error is returned.
+func (w *LineWriter) Encode(v interface{}) error {
+	return w.EncodeWithOption(v)
+}
+
+// EncodeWithOption call Encode with EncodeOption.
+func (w *LineWriter) EncodeWithOption(v interface{}, optFuncs ...EncodeOptionFunc) error {
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = 0
+	if w.enabledHTMLEscape {
+		ctx.Option.Flag |= encoder.HTMLEscapeOption
+	}
+	for _, optFunc := range optFuncs {
+		optFunc(ctx.Option)
+	}
+	err := w.encode(ctx, v)
+	encoder.ReleaseRuntimeContext(ctx)
+	return err
+}
+
+func (w *LineWriter) encode(ctx *encoder.RuntimeContext, v interface{}) error {
+	buf, err := encode(ctx, v)
+	if err != nil {
+		return err
+	}
+	buf = buf[:len(buf)-1]
+	if bytes.IndexByte(buf, '\n') >= 0 {
+		return fmt.Errorf("json: encoding of %T spans multiple lines", v)
+	}
+	buf = append(buf, '\n')
+	if _, err := w.w.Write(buf); err != nil {
+		return err
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package json
 import (
 	"github.com/goccy/go-json/internal/decoder"
 	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
 )
 
 type EncodeOption = encoder.Option
@@ -30,6 +31,70 @@ func Colorize(scheme *ColorScheme) EncodeOptionFunc {
 	}
 }
 
+// FloatPrecision specifies the number of digits after the decimal point used when encoding float values.
+// A negative value uses the smallest number of digits necessary to represent the value exactly ( default ).
+// The `precision=N` option of the struct tag takes precedence over this option.
+func FloatPrecision(prec int) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		if prec < 0 {
+			opt.Flag &^= encoder.FloatPrecisionOption
+			return
+		}
+		opt.Flag |= encoder.FloatPrecisionOption
+		opt.FloatPrecision = prec
+	}
+}
+
+// FloatExponentStyle specifies whether float values are encoded with an exponent.
+type FloatExponentStyle int
+
+const (
+	// FloatExponentAuto uses an exponent for large and small values like encoding/json ( default ).
+	FloatExponentAuto FloatExponentStyle = iota
+	// FloatExponentNever never uses an exponent (e.g. 1000000000000000000000).
+	FloatExponentNever
+	// FloatExponentAlways always uses an exponent (e.g. 1e+21).
+	FloatExponentAlways
+)
+
+// FloatExponent specifies the exponent style used when encoding float values.
+func FloatExponent(style FloatExponentStyle) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag &^= encoder.FloatExponentNeverOption | encoder.FloatExponentAlwaysOption
+		switch style {
+		case FloatExponentNever:
+			opt.Flag |= encoder.FloatExponentNeverOption
+		case FloatExponentAlways:
+			opt.Flag |= encoder.FloatExponentAlwaysOption
+		}
+	}
+}
+
+// NonFiniteFloatPolicy specifies how NaN, +Inf and -Inf are encoded.
+type NonFiniteFloatPolicy int
+
+const (
+	// NonFiniteFloatError returns an UnsupportedValueError ( default ).
+	NonFiniteFloatError NonFiniteFloatPolicy = iota
+	// NonFiniteFloatNull encodes non-finite values as null.
+	NonFiniteFloatNull
+	// NonFiniteFloatString encodes non-finite values as "NaN", "+Inf" and "-Inf".
+	NonFiniteFloatString
+)
+
+// NonFiniteFloat specifies the policy for encoding NaN, +Inf and -Inf.
+func NonFiniteFloat(policy NonFiniteFloatPolicy) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag &^= encoder.NonFiniteFloatNullOption | encoder.NonFiniteFloatStringOption
+		switch policy {
+		case NonFiniteFloatNull:
+			opt.Flag |= encoder.NonFiniteFloatNullOption
+		case NonFiniteFloatString:
+			opt.Flag |= encoder.NonFiniteFloatStringOption
+		}
+	}
+}
+
 type DecodeOption = decoder.Option
 type DecodeOptionFunc func(*DecodeOption)
 
@@ -44,3 +109,218 @@ func DecodeFieldPriorityFirstWin() DecodeOptionFunc {
 		opt.Flags |= decoder.FirstWinOption
 	}
 }
+
+// DecodeUseBigNumber causes numbers decoded into an interface{} to be stored as *big.Int
+// when they are integers that do not fit in int64, instead of losing precision as float64.
+func DecodeUseBigNumber() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.UseBigNumberOption
+	}
+}
+
+// DecodeUseInt64 causes integer numbers decoded into an interface{} to be stored as int64 instead of float64.
+// Integers that overflow int64 are stored as uint64 if possible, and numbers with a fraction or an exponent are stored as float64.
+// -0 is also stored as float64 to keep its sign.
+func DecodeUseInt64() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.UseInt64Option
+	}
+}
+
+// DecodeUseOrderedObject causes JSON objects decoded into an interface{} to be stored as OrderedObject
+// instead of map[string]interface{}, so that the order of keys is preserved.
+func DecodeUseOrderedObject() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.UseOrderedObjectOption
+	}
+}
+
+// MapKeyOrder sorts the keys of map values with less instead of the default byte order when encoding.
+// less receives the keys as they are written in JSON ( e.g. the decimal string for integer keys ).
+// This option is ignored when UnorderedMap is specified.
+func MapKeyOrder(less func(a, b string) bool) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.MapKeyOrderOption
+		opt.MapKeyLess = less
+	}
+}
+
+// NaturalMapKeyOrder sorts the keys of map values in natural order,
+// comparing runs of digits by their numeric value ( e.g. "item2" comes before "item10" ).
+func NaturalMapKeyOrder() EncodeOptionFunc {
+	return MapKeyOrder(encoder.NaturalMapKeyLess)
+}
+
+// NumericMapKeyOrder sorts the keys of map values by their numeric value ( e.g. "9" comes before "10" ).
+// Keys that are not numbers are placed after numeric keys in byte order.
+func NumericMapKeyOrder() EncodeOptionFunc {
+	return MapKeyOrder(encoder.NumericMapKeyLess)
+}
+
+// TimeFormat specifies the default format used when encoding time.Time values.
+// format is one of "unix", "unixmilli", "unixmicro" and "unixnano" to write the Unix time as a JSON number,
+// or a layout of time.Format ( e.g. "2006-01-02" ) or the name of a layout constant of the time package ( e.g. "RFC1123" )
+// to write a JSON string.
+// An empty format writes RFC 3339 string as time.Time.MarshalJSON does ( default ).
+// The `format=...` option of the struct tag takes precedence over this option,
+// and also applies to the time.Time values in the slices, the arrays and the maps of the field.
+// The format option takes the rest of the json tag, so it must be the last option and the layout can contain commas.
+// Since go vet reports the spaces in the json tag, a layout containing spaces is given by the name of the layout constant
+// ( e.g. `json:"date,omitempty,format=RFC1123"` ).
+func TimeFormat(format string) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.TimeFormatOption
+		opt.TimeFormat = runtime.TimeLayout(format)
+	}
+}
+
+// DecodeTimeFormat specifies the default format used when decoding time.Time values.
+// The format is the same as the TimeFormat encode option.
+func DecodeTimeFormat(format string) DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.TimeFormatOption
+		opt.TimeFormat = runtime.TimeLayout(format)
+	}
+}
+
+// BytesEncoding specifies the default encoding used when encoding []byte and [N]byte values.
+// name is one of "base64", "base64url", "rawbase64", "rawbase64url", "hex" and "array".
+// "array" writes a JSON array of numbers, and the others write a JSON string.
+// By default, []byte is written as padded base64 string and [N]byte is written as an array of numbers.
+// An unknown name is ignored.
+// The `format=...` option of the struct tag takes precedence over this option.
+func BytesEncoding(name string) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		enc, ok := runtime.BytesEncodingByName(name)
+		if !ok {
+			return
+		}
+		opt.Flag |= encoder.BytesEncodingOption
+		opt.BytesEncoding = enc
+	}
+}
+
+// DecodeBytesEncoding specifies the default encoding used when decoding []byte and [N]byte values.
+// The name is the same as the BytesEncoding encode option.
+func DecodeBytesEncoding(name string) DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		enc, ok := runtime.BytesEncodingByName(name)
+		if !ok {
+			return
+		}
+		opt.Flags |= decoder.BytesEncodingOption
+		opt.BytesEncoding = enc
+	}
+}
+
+// DecodeAnyBytesEncoding accepts any of the encodings of BytesEncoding when decoding []byte and [N]byte values.
+// A JSON string is decoded by the specified encoding first, and then by
+// base64, rawbase64, base64url, rawbase64url and hex in this order.
+// Since most hex strings are also valid base64 strings, use it with DecodeBytesEncoding("hex") to read hex strings.
+func DecodeAnyBytesEncoding() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.AnyBytesEncodingOption
+	}
+}
+
+// DecodeNoCopy makes Unmarshal decode data in place instead of copying it to append the nul sentinel for the end of input.
+// data is never modified, and the decoded strings and map keys are still allocated, so data can be reused after Unmarshal returns.
+//
+// data is decoded in place when it is a JSON object or array, or when it is followed by a zero byte within its capacity
+// ( e.g. buf[:n] of buf := make([]byte, n+1) ). Since the decoders check the bounds of data,
+// a truncated object or array is reported as the unexpected end of JSON input.
+// Other values, such as a top-level string or number, are copied as usual.
+// This option has no effect on Decoder, LineReader and SequenceDecoder, which decode from their own buffers.
+func DecodeNoCopy() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.NoCopyOption
+	}
+}
+
+// DecodeZeroCopy makes Unmarshal decode data in place as DecodeNoCopy does, and also makes the decoded strings,
+// map keys and json.Number values that have no escape sequences point into data instead of being allocated.
+// Strings with escape sequences are still allocated, and data is never modified.
+//
+// The decoded values share the memory of data, so data must not be modified or reused while they are in use,
+// and keeping any of them alive keeps the whole data alive.
+// This option has no effect on Decoder, LineReader and SequenceDecoder, which decode from their own buffers.
+func DecodeZeroCopy() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.NoCopyOption | decoder.ZeroCopyOption
+	}
+}
+
+// DecodeParallel makes Unmarshal decode the elements of a top-level JSON array concurrently when decoding into a slice.
+// The array is first split into elements by a scan, and then chunks of the elements are decoded by workers goroutines
+// into a newly allocated slice. If workers is zero or negative, runtime.GOMAXPROCS(0) is used.
+//
+// If decoding fails, the error for the element of the smallest index is returned as sequential decoding does, and the slice is not modified.
+// This option only pays off for large arrays of expensive elements, and has no effect on Decoder.
+func DecodeParallel(workers int) DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.ParallelOption
+		opt.Parallelism = workers
+	}
+}
+
+// Parallel encodes the elements of a top-level slice concurrently by workers goroutines.
+// The slice is partitioned into contiguous chunks, each chunk is encoded on its own context, and the results are joined in order,
+// so the output is the same as sequential encoding. If workers is zero or negative, runtime.GOMAXPROCS(0) is used.
+//
+// Only a top-level slice ( or a pointer to a slice ) given to Marshal, MarshalIndent or Encoder is split.
+// Arrays, structs and the slices nested in them are encoded sequentially as usual.
+// Maps are out of scope and are also encoded sequentially: their keys are sorted over the whole map,
+// so the entries can't be encoded as independent chunks in the output order.
+// The elements must be safe to encode concurrently, for example, MarshalJSON methods and the comparison of MapKeyOrder are called from multiple goroutines.
+//
+// The chunks are joined in memory, so the split slice is buffered in full and Encoder.SetFlushThreshold does not stream it.
+// Use either Parallel or SetFlushThreshold for a huge slice, not both.
+func Parallel(workers int) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.ParallelOption
+		opt.Parallelism = workers
+	}
+}
+
+// NilAsEmpty encodes nil slices as [] and nil maps as {} instead of null.
+// It is applied to all values including the values stored in interface{}.
+// A nil []byte is encoded as an empty value of its encoding ( e.g. "" for base64 ).
+// The `nilasempty` option of the struct tag enables it for the field value and the slices and maps nested in it.
+func NilAsEmpty() EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.NilAsEmptyOption
+	}
+}
+
+// StringEscapePolicy specifies which characters in JSON strings are escaped.
+type StringEscapePolicy int
+
+const (
+	// StringEscapeHTML escapes <, >, & and U+2028, U+2029 in addition to the characters required by JSON ( default ).
+	StringEscapeHTML StringEscapePolicy = iota
+	// StringEscapeMinimal escapes only the characters required by JSON: ", \ and control characters.
+	StringEscapeMinimal
+	// StringEscapeASCII escapes all non-ASCII characters as \uXXXX in addition to the characters required by JSON.
+	StringEscapeASCII
+	// StringEscapeJavaScript escapes U+2028 and U+2029 in addition to the characters required by JSON.
+	// This is the same as Encoder.SetEscapeHTML(false).
+	StringEscapeJavaScript
+)
+
+// StringEscape specifies the policy for escaping characters in JSON strings.
+// It is applied to string values and map keys, and takes precedence over Encoder.SetEscapeHTML.
+// Struct field names are escaped only by StringEscapeHTML and StringEscapeASCII,
+// and the output of MarshalJSON only by StringEscapeHTML.
+func StringEscape(policy StringEscapePolicy) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag &^= encoder.HTMLEscapeOption | encoder.EscapeMinimalOption | encoder.EscapeASCIIOption
+		switch policy {
+		case StringEscapeHTML:
+			opt.Flag |= encoder.HTMLEscapeOption
+		case StringEscapeMinimal:
+			opt.Flag |= encoder.EscapeMinimalOption
+		case StringEscapeASCII:
+			opt.Flag |= encoder.EscapeASCIIOption
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package json
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// Precompile compiles the encoders and the decoders of the given types ahead of time,
+// so that the first Marshal or Unmarshal of them does not pay for the compilation.
+// Each argument is either a value of the type or its reflect.Type. For a type T or *T,
+// the encoders of T and *T and the decoder into *T are compiled.
+// It returns the first error, such as *UnsupportedTypeError or *UnmarshalTypeError, for the types that cannot be encoded or decoded.
+func Precompile(types ...interface{}) error {
+	for _, v := range types {
+		typ, ok := v.(reflect.Type)
+		if !ok {
+			typ = reflect.TypeOf(v)
+		}
+		if typ == nil {
+			return fmt.Errorf("json: cannot precompile nil")
+		}
+		if err := precompile(typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func precompile(typ reflect.Type) error {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	ptrType := reflect.PtrTo(typ)
+	for _, t := range []reflect.Type{typ, ptrType} {
+		if _, err := encoder.CompileToGetCodeSet(uintptr(unsafe.Pointer(runtime.Type2RType(t)))); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.CompileToGetDecoder(runtime.Type2RType(ptrType)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CachedTypes returns the types whose encoders and decoders are already compiled, sorted by their names.
+// The decode types are the types decoded into, that is T for Unmarshal into *T.
+func CachedTypes() (encode []reflect.Type, decode []reflect.Type) {
+	for _, typ := range encoder.CachedTypes() {
+		encode = append(encode, runtime.RType2Type(typ))
+	}
+	for _, typ := range decoder.CachedTypes() {
+		decode = append(decode, runtime.RType2Type(typ).Elem())
+	}
+	sortTypes(encode)
+	sortTypes(decode)
+	return encode, decode
+}
+
+func sortTypes(types []reflect.Type) {
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+}
+
+// SetCacheLimit bounds the number of the encoders and the decoders cached for the types that are not
+// compiled into the binary, such as the types created by reflect.StructOf or loaded by plugins.
+// When the bound is reached, the oldest entries are evicted and compiled again on their next use.
+// n <= 0 means unbounded, which is the default.
+func SetCacheLimit(n int) {
+	encoder.SetCacheLimit(n)
+	decoder.SetCacheLimit(n)
+}
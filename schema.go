@@ -0,0 +1,34 @@
+package json
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// Schema returns the JSON Schema (draft 2020-12) of the values of v's type encoded by Marshal.
+// v is either a value of the type or its reflect.Type.
+//
+// The properties of the objects are exactly the fields written by the encoder:
+// the names of the struct tags, the fields promoted from the embedded structs
+// and the conflicting fields removed as Marshal does.
+// The fields without omitempty are required, except those promoted through the embedded pointers,
+// and the values with the string option are strings.
+// The named struct types are defined in $defs and referred by $ref.
+// The values of the types implementing json.Marshaler are not constrained since they are determined by MarshalJSON.
+func Schema(v interface{}) ([]byte, error) {
+	typ, ok := v.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(v)
+	}
+	if typ == nil {
+		return nil, fmt.Errorf("json: cannot make schema of nil")
+	}
+	schema, err := encoder.Schema(runtime.Type2RType(typ))
+	if err != nil {
+		return nil, err
+	}
+	return Marshal(schema)
+}
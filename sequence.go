@@ -0,0 +1,136 @@
+package json
+
+import (
+	"io"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/encoder"
+)
+
+// recordSeparator is the RS byte that starts each record of a JSON text sequence.
+const recordSeparator = 0x1E
+
+// A SequenceDecoder reads JSON text sequences ( RFC 7464, application/json-seq ) from an input stream.
+//
+// As the RFC specifies, records that are truncated or are not valid JSON texts are skipped,
+// and decoding continues from the next record.
+type SequenceDecoder struct {
+	s   *decoder.Stream
+	err error
+}
+
+// NewSequenceDecoder returns a new decoder that reads JSON text sequences from r.
+func NewSequenceDecoder(r io.Reader) *SequenceDecoder {
+	return &SequenceDecoder{s: decoder.NewStream(r)}
+}
+
+// Decode reads the next record and stores its JSON text in the value pointed to by v.
+// It returns io.EOF when there are no more records.
+func (d *SequenceDecoder) Decode(v interface{}) error {
+	return d.DecodeWithOption(v)
+}
+
+// DecodeWithOption call Decode with DecodeOption.
+func (d *SequenceDecoder) DecodeWithOption(v interface{}, optFuncs ...DecodeOptionFunc) error {
+	header := (*emptyInterface)(unsafe.Pointer(&v))
+	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
+		return err
+	}
+	for {
+		if d.err != nil {
+			return d.err
+		}
+		record, err := d.s.ReadRecord(recordSeparator)
+		if err != nil {
+			d.err = err
+			if err != io.EOF || len(record) == 0 {
+				return err
+			}
+		}
+		if isTruncatedRecord(record) {
+			continue
+		}
+		if err := unmarshalRecord(record, v, optFuncs); err != nil {
+			if _, ok := err.(*SyntaxError); ok {
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// isTruncatedRecord reports whether record has no JSON text or may be truncated.
+// Top-level numbers, true, false and null must be followed by whitespace, otherwise they may be truncated ( RFC 7464 section 2.4 ).
+func isTruncatedRecord(record []byte) bool {
+	start := 0
+	for start < len(record) && isWhiteSpace(record[start]) {
+		start++
+	}
+	if start == len(record) {
+		return true
+	}
+	switch record[start] {
+	case '{', '[', '"':
+		return false
+	}
+	return !isWhiteSpace(record[len(record)-1])
+}
+
+func isWhiteSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// A SequenceEncoder writes JSON text sequences ( RFC 7464, application/json-seq ) to an output stream.
+type SequenceEncoder struct {
+	w                 io.Writer
+	enabledHTMLEscape bool
+}
+
+// NewSequenceEncoder returns a new encoder that writes JSON text sequences to w.
+func NewSequenceEncoder(w io.Writer) *SequenceEncoder {
+	return &SequenceEncoder{w: w, enabledHTMLEscape: true}
+}
+
+// SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings.
+func (e *SequenceEncoder) SetEscapeHTML(on bool) {
+	e.enabledHTMLEscape = on
+}
+
+// Encode writes the JSON encoding of v to the stream as a record, that is RS, the JSON text and a newline character.
+func (e *SequenceEncoder) Encode(v interface{}) error {
+	return e.EncodeWithOption(v)
+}
+
+// EncodeWithOption call Encode with EncodeOption.
+func (e *SequenceEncoder) EncodeWithOption(v interface{}, optFuncs ...EncodeOptionFunc) error {
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = 0
+	if e.enabledHTMLEscape {
+		ctx.Option.Flag |= encoder.HTMLEscapeOption
+	}
+	for _, optFunc := range optFuncs {
+		optFunc(ctx.Option)
+	}
+	err := e.encode(ctx, v)
+	encoder.ReleaseRuntimeContext(ctx)
+	return err
+}
+
+func (e *SequenceEncoder) encode(ctx *encoder.RuntimeContext, v interface{}) error {
+	buf, err := encode(ctx, v)
+	if err != nil {
+		return err
+	}
+	// replace the trailing comma with a newline character and insert RS at the head
+	buf[len(buf)-1] = '\n'
+	buf = append(buf, 0)
+	copy(buf[1:], buf)
+	buf[0] = recordSeparator
+	ctx.Buf = buf
+	if _, err := e.w.Write(buf); err != nil {
+		return err
+	}
+	return nil
+}
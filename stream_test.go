@@ -7,9 +7,11 @@ package json_test
 import (
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"math"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -174,6 +176,73 @@ func TestEncoderSetEscapeHTML(t *testing.T) {
 	}
 }
 
+type recordWriter struct {
+	bytes.Buffer
+	sizes []int
+}
+
+func (w *recordWriter) Write(p []byte) (int, error) {
+	w.sizes = append(w.sizes, len(p))
+	return w.Buffer.Write(p)
+}
+
+func TestEncoderSetFlushThreshold(t *testing.T) {
+	type row struct {
+		ID   int      `json:"id"`
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	rows := make([]row, 1000)
+	for i := range rows {
+		rows[i] = row{ID: i, Name: "name" + strconv.Itoa(i), Tags: []string{"a", "b"}}
+	}
+	v := struct {
+		Rows  []row     `json:"rows"`
+		Array [300]int8 `json:"array"`
+		Last  []string  `json:"last"`
+	}{Rows: rows, Last: []string{}}
+
+	for _, indent := range []string{"", "  "} {
+		expected, err := json.MarshalIndent(v, "", indent)
+		if indent == "" {
+			expected, err = json.Marshal(v)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var w recordWriter
+		enc := json.NewEncoder(&w)
+		enc.SetIndent("", indent)
+		enc.SetFlushThreshold(1024)
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+		if got := w.String(); got != string(expected)+"\n" {
+			t.Fatalf("indent %q: unexpected output %s", indent, got)
+		}
+		if len(w.sizes) < len(expected)/2048 {
+			t.Fatalf("indent %q: expected to be flushed but written %d times", indent, len(w.sizes))
+		}
+		for _, size := range w.sizes {
+			if size > 1024+128 {
+				t.Fatalf("indent %q: written %d bytes at once", indent, size)
+			}
+		}
+	}
+
+	t.Run("small value", func(t *testing.T) {
+		var w recordWriter
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(1024)
+		if err := enc.Encode([]int{1, 2, 3}); err != nil {
+			t.Fatal(err)
+		}
+		if w.String() != "[1,2,3]\n" || len(w.sizes) != 1 {
+			t.Fatalf("unexpected output %q written %d times", w.String(), len(w.sizes))
+		}
+	})
+}
+
 func nlines(s string, n int) string {
 	if n <= 0 {
 		return ""
@@ -528,3 +597,265 @@ func TestIssue278(t *testing.T) {
 		t.Errorf("string %q; want = %q", got, want)
 	}
 }
+
+func TestLineReader(t *testing.T) {
+	type T struct {
+		A int
+	}
+	const input = "{\"A\":1}\n\n  \r\n{\"A\":2}\r\n{\"A\":\n[1]\n{\"A\":3}"
+	t.Run("error", func(t *testing.T) {
+		r := json.NewLineReader(strings.NewReader(input))
+		var got []int
+		for {
+			var v T
+			err := r.Next(&v)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				lineErr, ok := err.(*json.LineError)
+				if !ok {
+					t.Fatalf("unexpected error type %T", err)
+				}
+				assertEq(t, "line", 5, lineErr.Line)
+				assertEq(t, "line", 5, r.Line())
+				break
+			}
+			got = append(got, v.A)
+		}
+		assertEq(t, "values", "[1 2]", fmt.Sprint(got))
+	})
+	t.Run("SkipMalformedLines", func(t *testing.T) {
+		r := json.NewLineReader(strings.NewReader(input))
+		r.SkipMalformedLines()
+		var got []int
+		for {
+			var v T
+			err := r.Next(&v)
+			if err == io.EOF {
+				break
+			}
+			assertErr(t, err)
+			got = append(got, v.A)
+		}
+		assertEq(t, "values", "[1 2 3]", fmt.Sprint(got))
+		assertEq(t, "line", 7, r.Line())
+	})
+	t.Run("DecodeZeroCopy", func(t *testing.T) {
+		r := json.NewLineReader(strings.NewReader("{\"a\":\"first\"}\n{\"a\":\"XXXXX\"}\n"))
+		var m1, m2 map[string]string
+		assertErr(t, r.NextWithOption(&m1, json.DecodeZeroCopy()))
+		assertErr(t, r.NextWithOption(&m2, json.DecodeZeroCopy()))
+		// the values of the first line are not overwritten by the second line
+		assertEq(t, "first", "first", m1["a"])
+		assertEq(t, "second", "XXXXX", m2["a"])
+	})
+	t.Run("long lines", func(t *testing.T) {
+		var (
+			input    strings.Builder
+			expected []string
+		)
+		for i := 0; i < 100; i++ {
+			v := strings.Repeat(string(rune('a'+i%26)), i*37)
+			fmt.Fprintf(&input, "\"%s\"\n", v)
+			expected = append(expected, v)
+		}
+		r := json.NewLineReader(strings.NewReader(input.String()))
+		var got []string
+		for {
+			var v string
+			err := r.Next(&v)
+			if err == io.EOF {
+				break
+			}
+			assertErr(t, err)
+			got = append(got, v)
+		}
+		assertEq(t, "values", fmt.Sprint(expected), fmt.Sprint(got))
+	})
+	t.Run("multiple values in a line", func(t *testing.T) {
+		r := json.NewLineReader(strings.NewReader("1 2\n"))
+		var v int
+		if err := r.Next(&v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestLineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := json.NewLineWriter(&buf)
+	assertErr(t, w.Encode(struct {
+		A []int `json:"a"`
+	}{[]int{1, 2}}))
+	assertErr(t, w.Encode(json.RawMessage("{\n  \"b\": \"<\\n>\"\n}")))
+	w.SetEscapeHTML(false)
+	assertErr(t, w.Encode("<\n>"))
+	assertEq(t, "lines", "{\"a\":[1,2]}\n{\"b\":\"\\u003c\\n\\u003e\"}\n\"<\\n>\"\n", buf.String())
+
+	buf.Reset()
+	if err := w.EncodeWithOption(1, json.Colorize(&json.ColorScheme{Int: json.ColorFormat{Header: "\n"}})); err == nil {
+		t.Fatal("expected error")
+	}
+	assertEq(t, "lines", "", buf.String())
+}
+
+func TestSequenceDecoder(t *testing.T) {
+	input := "\x1e{\"A\":1}\n\x1e\x1e{\"A\":\x1e12\x1e[1]\n\x1e{\"A\":2}"
+	dec := json.NewSequenceDecoder(strings.NewReader(input))
+	var got []int
+	for {
+		var v struct{ A int }
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// valid JSON texts are not skipped even if they cannot be stored in v
+			if _, ok := err.(*json.UnmarshalTypeError); !ok {
+				t.Fatalf("unexpected error %v", err)
+			}
+			continue
+		}
+		got = append(got, v.A)
+	}
+	assertEq(t, "values", "[1 2]", fmt.Sprint(got))
+
+	dec = json.NewSequenceDecoder(strings.NewReader("\x1e1\n\x1e2\n\x1e3"))
+	var sum int
+	for {
+		var v int
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		assertErr(t, err)
+		sum += v
+	}
+	// the last number can be truncated because it is not followed by whitespace
+	assertEq(t, "sum", 3, sum)
+
+	dec = json.NewSequenceDecoder(strings.NewReader("\x1e{\"a\":\"first\"}\n\x1e{\"a\":\"XXXXX\"}\n"))
+	var m1, m2 map[string]string
+	assertErr(t, dec.DecodeWithOption(&m1, json.DecodeZeroCopy()))
+	assertErr(t, dec.DecodeWithOption(&m2, json.DecodeZeroCopy()))
+	// the values of the first record are not overwritten by the second record
+	assertEq(t, "first", "first", m1["a"])
+	assertEq(t, "second", "XXXXX", m2["a"])
+}
+
+func TestSequenceEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := json.NewSequenceEncoder(&buf)
+	assertErr(t, enc.Encode(1))
+	assertErr(t, enc.Encode([]string{"<a>"}))
+	enc.SetEscapeHTML(false)
+	assertErr(t, enc.Encode(struct{ A string }{"<a>"}))
+	assertEq(t, "seq", "\x1e1\n\x1e[\"\\u003ca\\u003e\"]\n\x1e{\"A\":\"<a>\"}\n", buf.String())
+
+	dec := json.NewSequenceDecoder(&buf)
+	var v interface{}
+	assertErr(t, dec.Decode(&v))
+	assertEq(t, "seq", float64(1), v)
+}
+
+func TestEncoderWriteToken(t *testing.T) {
+	write := func(t *testing.T, enc *json.Encoder) {
+		assertErr(t, enc.BeginObject())
+		assertErr(t, enc.Key("a"))
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		assertErr(t, enc.WriteToken(1))
+		assertErr(t, enc.Encode(struct{ B []int }{[]int{2, 3}}))
+		assertErr(t, enc.BeginArray())
+		assertErr(t, enc.EndArray())
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertErr(t, enc.WriteToken("<b>"))
+		assertErr(t, enc.WriteToken("c"))
+		assertErr(t, enc.Key("d"))
+		assertErr(t, enc.BeginObject())
+		assertErr(t, enc.EndObject())
+		assertErr(t, enc.EndObject())
+	}
+	t.Run("compact", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		write(t, enc)
+		assertErr(t, enc.WriteToken(nil))
+		assertEq(t, "token", "{\"a\":[1,{\"B\":[2,3]},[]],\"\\u003cb\\u003e\":\"c\",\"d\":{}}\nnull\n", buf.String())
+	})
+	t.Run("indent", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent(">", "  ")
+		write(t, enc)
+		want := `{
+>  "a": [
+>    1,
+>    {
+>      "B": [
+>        2,
+>        3
+>      ]
+>    },
+>    []
+>  ],
+>  "\u003cb\u003e": "c",
+>  "d": {}
+>}
+`
+		assertEq(t, "token", want, buf.String())
+	})
+	t.Run("error", func(t *testing.T) {
+		enc := json.NewEncoder(ioutil.Discard)
+		if err := enc.EndObject(); err == nil {
+			t.Fatal("expected error")
+		}
+		if err := enc.Key("a"); err == nil {
+			t.Fatal("expected error")
+		}
+		assertErr(t, enc.BeginObject())
+		if err := enc.WriteToken(1); err == nil {
+			t.Fatal("expected error")
+		}
+		if err := enc.EndArray(); err == nil {
+			t.Fatal("expected error")
+		}
+		assertErr(t, enc.Key("a"))
+		if err := enc.Key("b"); err == nil {
+			t.Fatal("expected error")
+		}
+		if err := enc.EndObject(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("value error", func(t *testing.T) {
+		for _, indent := range []bool{false, true} {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			if indent {
+				enc.SetIndent("", " ")
+			}
+			assertErr(t, enc.BeginObject())
+			assertErr(t, enc.Key("a"))
+			assertErr(t, enc.BeginArray())
+			assertErr(t, enc.Encode(1))
+			if err := enc.Encode(func() {}); err == nil {
+				t.Fatal("expected error")
+			}
+			assertErr(t, enc.Encode(2))
+			assertErr(t, enc.EndArray())
+			assertErr(t, enc.Key("c"))
+			if err := enc.Encode(math.NaN()); err == nil {
+				t.Fatal("expected error")
+			}
+			// the key is still waiting for the value
+			assertErr(t, enc.Encode(3))
+			assertErr(t, enc.EndObject())
+			want := "{\"a\":[1,2],\"c\":3}\n"
+			if indent {
+				want = "{\n \"a\": [\n  1,\n  2\n ],\n \"c\": 3\n}\n"
+			}
+			assertEq(t, "token", want, buf.String())
+		}
+	})
+}
@@ -0,0 +1,339 @@
+package jsongen_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/goccy/go-json/test/jsongen"
+)
+
+// the types without the generated methods, which are encoded and decoded by the runtime
+type (
+	plainOrder    jsongen.Order
+	plainConflict jsongen.Conflict
+	plainNested   jsongen.Nested
+	plainRecord   jsongen.Record
+)
+
+func newOrder() jsongen.Order {
+	return jsongen.Order{
+		Base:     jsongen.Base{ID: 42, CreatedBy: "<admin>"},
+		Audit:    &jsongen.Audit{UpdatedBy: "bot \"x\"", Revision: 3},
+		Status:   "paid",
+		Level:    -2,
+		Paid:     true,
+		Total:    12.5,
+		Quantity: 7,
+		Quoted:   "a&b",
+		Items:    []jsongen.Item{{Name: "apple", Price: 1e21}, {Name: "pear", Price: 0.000001}},
+		Ref:      &jsongen.Item{Name: "ref"},
+		Any:      []interface{}{"x", 1.5, nil},
+		Amount:   "12.30",
+		At:       time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC),
+		Date:     time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		Ratio:    1.23456,
+		Raw:      json.RawMessage(`{"a": [1, 2]}`),
+		Ignored:  "ignored",
+	}
+}
+
+func newRecord() jsongen.Record {
+	score, count := 2.345, -7
+	at := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	return jsongen.Record{
+		Data:   []byte("data"),
+		Score:  &score,
+		Count:  &count,
+		Times:  []time.Time{at, at.Add(time.Millisecond)},
+		At:     &at,
+		Matrix: [][]int{{1, 2}, nil, {}},
+		Points: []*jsongen.Point{{X: 1, Y: 2}, nil},
+		Groups: [][]jsongen.Point{{{X: 3}}, nil},
+		Pair:   [2]int{4, 5},
+		Temps:  []jsongen.Celsius{21.5, -3},
+		Next:   &jsongen.Record{Names: []string{"next"}},
+	}
+}
+
+func TestGeneratedMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		generated interface{}
+		plain     interface{}
+	}{
+		{"order", newOrder(), plainOrder(newOrder())},
+		{"empty order", jsongen.Order{}, plainOrder{}},
+		{"conflict", jsongen.Conflict{
+			Left:  jsongen.Left{Name: "l", Value: 1, Both: "lb"},
+			Right: jsongen.Right{Name: "r", Value: 2, Both: "rb"},
+			Extra: "e",
+		}, plainConflict{
+			Left:  jsongen.Left{Name: "l", Value: 1, Both: "lb"},
+			Right: jsongen.Right{Name: "r", Value: 2, Both: "rb"},
+			Extra: "e",
+		}},
+		{"nested", jsongen.Nested{Name: "N", Label: "l"}, plainNested{Name: "N", Label: "l"}},
+		{"record", newRecord(), plainRecord(newRecord())},
+		{"empty record", jsongen.Record{}, plainRecord{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected, err := json.Marshal(test.plain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(test.generated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(expected) {
+				t.Errorf("expected %s but got %s", expected, got)
+			}
+			indented, err := json.MarshalIndent(test.generated, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the runtime does not indent the values of the interface fields as json.Indent does
+			var expectedIndented bytes.Buffer
+			if err := json.Indent(&expectedIndented, expected, "", "  "); err != nil {
+				t.Fatal(err)
+			}
+			if string(indented) != expectedIndented.String() {
+				t.Errorf("expected %s but got %s", expectedIndented.String(), indented)
+			}
+		})
+	}
+}
+
+func TestGeneratedUnmarshalJSON(t *testing.T) {
+	t.Run("order", func(t *testing.T) {
+		data, err := json.Marshal(plainOrder(newOrder()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, src := range []string{
+			string(data),
+			`null`,
+			`{}`,
+			`{"ID":1,"updatedby":"u","revision":"5","STATUS":"s","quantity":"3","note":"a\nbé","level":1.0}`,
+			`{"id":null,"paid":null,"tags":null,"ref":null,"status":"😀","total":1e2,"unknown":{"x":[1,{}]}}`,
+		} {
+			var (
+				generated jsongen.Order
+				plain     plainOrder
+			)
+			errGenerated := json.Unmarshal([]byte(src), &generated)
+			errPlain := json.Unmarshal([]byte(src), &plain)
+			if (errGenerated == nil) != (errPlain == nil) {
+				t.Fatalf("%s: expected error %v but got %v", src, errPlain, errGenerated)
+			}
+			if !reflect.DeepEqual(plainOrder(generated), plain) {
+				t.Errorf("%s: expected %+v but got %+v", src, plain, generated)
+			}
+		}
+	})
+	t.Run("conflict", func(t *testing.T) {
+		src := `{"name":"a","Name":"b","Both":"c","Value":1,"both":"d"}`
+		var (
+			generated jsongen.Conflict
+			plain     plainConflict
+		)
+		if err := json.Unmarshal([]byte(src), &generated); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(src), &plain); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(plainConflict(generated), plain) {
+			t.Errorf("expected %+v but got %+v", plain, generated)
+		}
+	})
+	t.Run("nested", func(t *testing.T) {
+		src := `{"deep":"d","name":"n","NAME":"N","kind":"k","label":"l"}`
+		var (
+			generated jsongen.Nested
+			plain     plainNested
+		)
+		if err := json.Unmarshal([]byte(src), &generated); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(src), &plain); err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := json.Marshal(plain)
+		got, _ := json.Marshal(generated)
+		if string(got) != string(expected) {
+			t.Errorf("expected %s but got %s", expected, got)
+		}
+	})
+	t.Run("record", func(t *testing.T) {
+		data, err := json.Marshal(plainRecord(newRecord()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, src := range []string{
+			string(data),
+			`null`,
+			`{"data":null,"score":null,"count":null,"times":null,"at":null,"matrix":null,"points":null,"next":null}`,
+			`{"data":"","blob":"YWI=","count":"null","times":[],"matrix":[[],[3],null],"points":[null,{"x":9}],"names":["a"]}`,
+			`{"temps":[1.25,null],"score":1e3,"count":" 12","times":[1.5,-2],"matrix":[[1,2,3]],"points":[{"Y":1},{},{"X":2}],"groups":[[{"X":1}]]}`,
+		} {
+			// the values are decoded on top of the existing ones
+			generated, plain := newRecord(), plainRecord(newRecord())
+			errGenerated := json.Unmarshal([]byte(src), &generated)
+			errPlain := json.Unmarshal([]byte(src), &plain)
+			if (errGenerated == nil) != (errPlain == nil) {
+				t.Fatalf("%s: expected error %v but got %v", src, errPlain, errGenerated)
+			}
+			if !reflect.DeepEqual(plainRecord(generated), plain) {
+				t.Errorf("%s: expected %+v but got %+v", src, plain, generated)
+			}
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		for _, src := range []string{
+			`{"id":"x"}`,
+			`{"quantity":3}`,
+			`[]`,
+			`{"level":1000}`,
+		} {
+			var (
+				generated jsongen.Order
+				plain     plainOrder
+			)
+			errGenerated := json.Unmarshal([]byte(src), &generated)
+			errPlain := json.Unmarshal([]byte(src), &plain)
+			if errGenerated == nil || errPlain == nil {
+				t.Errorf("%s: expected errors but got %v and %v", src, errGenerated, errPlain)
+			}
+		}
+	})
+	t.Run("record error", func(t *testing.T) {
+		for _, src := range []string{
+			`{"data":"!"}`,
+			`{"count":12}`,
+			`{"times":["x"]}`,
+			`{"matrix":[["1"]]}`,
+			`{"points":[1]}`,
+			`{"names":{}}`,
+			`{"next":{"pair":[1,2,3}}`,
+		} {
+			var (
+				generated jsongen.Record
+				plain     plainRecord
+			)
+			// the runtime overwrites the struct and the field names in the errors of UnmarshalJSON
+			errGenerated := generated.UnmarshalJSON([]byte(src))
+			errPlain := json.Unmarshal([]byte(src), &plain)
+			if errGenerated == nil || errPlain == nil {
+				t.Errorf("%s: expected errors but got %v and %v", src, errGenerated, errPlain)
+			} else if errGenerated.Error() != strings.Replace(errPlain.Error(), "plainRecord", "Record", 1) {
+				t.Errorf("%s: expected error %v but got %v", src, errPlain, errGenerated)
+			}
+		}
+	})
+}
+
+func Benchmark_Encode_Order_Generated(b *testing.B) {
+	v := newOrder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Encode_Order_Runtime(b *testing.B) {
+	v := plainOrder(newOrder())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Decode_Order_Generated(b *testing.B) {
+	data, err := json.Marshal(plainOrder(newOrder()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var v jsongen.Order
+		if err := v.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Decode_Order_Runtime(b *testing.B) {
+	data, err := json.Marshal(plainOrder(newOrder()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var v plainOrder
+		if err := json.Unmarshal(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Encode_Record_Generated(b *testing.B) {
+	v := newRecord()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Encode_Record_Runtime(b *testing.B) {
+	v := plainRecord(newRecord())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Decode_Record_Generated(b *testing.B) {
+	data, err := json.Marshal(plainRecord(newRecord()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var v jsongen.Record
+		if err := v.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Decode_Record_Runtime(b *testing.B) {
+	data, err := json.Marshal(plainRecord(newRecord()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var v plainRecord
+		if err := json.Unmarshal(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
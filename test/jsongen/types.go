@@ -0,0 +1,123 @@
+// Package jsongen has the types whose methods are generated by cmd/jsongen, to test that the generated methods behave as the runtime does.
+package jsongen
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+//go:generate go run ../../cmd/jsongen -type=Order,Conflict,Nested,Record -output=types_json.go
+
+type Status string
+
+type Level int8
+
+type Item struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+type Base struct {
+	ID        int64  `json:"id"`
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+type Audit struct {
+	UpdatedBy string
+	Revision  uint16 `json:"revision,string"`
+}
+
+type Order struct {
+	Base
+	*Audit
+	Status   Status          `json:"status"`
+	Level    Level           `json:"level,omitempty"`
+	Paid     bool            `json:"paid"`
+	Total    float32         `json:"total"`
+	Quantity uint            `json:"quantity,string"`
+	Note     string          `json:"note,omitempty"`
+	Quoted   string          `json:"quoted,string,omitempty"`
+	Items    []Item          `json:"items"`
+	Tags     []string        `json:"tags,omitempty"`
+	Ref      *Item           `json:"ref,omitempty"`
+	Any      interface{}     `json:"any"`
+	Amount   json.Number     `json:"amount"`
+	At       time.Time       `json:"at"`
+	Date     time.Time       `json:"date,format=2006-01-02"`
+	Ratio    float64         `json:"ratio,precision=2"`
+	Raw      json.RawMessage `json:"raw,omitempty"`
+	Ignored  string          `json:"-"`
+	private  string
+}
+
+type Left struct {
+	Name  string `json:"name"`
+	Value int
+	Both  string
+}
+
+type Right struct {
+	Name  string
+	Value int
+	Both  string
+}
+
+type Conflict struct {
+	Left
+	Right
+	Extra string `json:"Both"`
+}
+
+type inner struct {
+	Deep string `json:"deep"`
+	Name string `json:"name"`
+}
+
+type Middle struct {
+	*inner
+	Kind string `json:"kind"`
+}
+
+type Nested struct {
+	Middle
+	Name  string `json:"NAME"`
+	Label string `json:"label"`
+}
+
+type Point struct {
+	X int
+	Y int
+}
+
+// Celsius has the marshaler methods of the pointer receiver.
+type Celsius float64
+
+func (c *Celsius) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(*c), 'f', 1, 64)), nil
+}
+
+func (c *Celsius) UnmarshalJSON(data []byte) error {
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*c = Celsius(f)
+	return nil
+}
+
+type Record struct {
+	Data   []byte      `json:"data"`
+	Blob   []byte      `json:"blob,nilasempty"`
+	Score  *float64    `json:"score,precision=1"`
+	Count  *int        `json:"count,string"`
+	Times  []time.Time `json:"times,format=unixmilli"`
+	At     *time.Time  `json:"at,omitempty"`
+	Matrix [][]int     `json:"matrix"`
+	Points []*Point    `json:"points"`
+	Groups [][]Point   `json:"groups,nilasempty"`
+	Names  []string    `json:"names,nilasempty"`
+	Pair   [2]int      `json:"pair"`
+	Temps  []Celsius   `json:"temps"`
+	Next   *Record     `json:"next,omitempty"`
+}
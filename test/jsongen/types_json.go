@@ -0,0 +1,1229 @@
+// Code generated by jsongen. DO NOT EDIT.
+
+package jsongen
+
+import (
+	"github.com/goccy/go-json/jsongen"
+	"time"
+)
+
+// MarshalJSON encodes v with the fields resolved as the encoder of go-json does for Order.
+// The options of MarshalWithOption are not applied.
+func (v Order) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(func(b []byte) ([]byte, error) {
+		return jsongenAppendOrder(b, &v)
+	})
+}
+
+// UnmarshalJSON decodes data into v with the fields resolved as the decoder of go-json does for Order.
+// The options of UnmarshalWithOption are not applied.
+func (v *Order) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	if err := jsongenDecodeOrder(&l, v); err != nil {
+		return err
+	}
+	return l.End()
+}
+
+// MarshalJSON encodes v with the fields resolved as the encoder of go-json does for Conflict.
+// The options of MarshalWithOption are not applied.
+func (v Conflict) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(func(b []byte) ([]byte, error) {
+		return jsongenAppendConflict(b, &v)
+	})
+}
+
+// UnmarshalJSON decodes data into v with the fields resolved as the decoder of go-json does for Conflict.
+// The options of UnmarshalWithOption are not applied.
+func (v *Conflict) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	if err := jsongenDecodeConflict(&l, v); err != nil {
+		return err
+	}
+	return l.End()
+}
+
+// MarshalJSON encodes v with the fields resolved as the encoder of go-json does for Nested.
+// The options of MarshalWithOption are not applied.
+func (v Nested) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(func(b []byte) ([]byte, error) {
+		return jsongenAppendNested(b, &v)
+	})
+}
+
+// UnmarshalJSON decodes data into v with the fields resolved as the decoder of go-json does for Nested.
+// The options of UnmarshalWithOption are not applied.
+func (v *Nested) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	if err := jsongenDecodeNested(&l, v); err != nil {
+		return err
+	}
+	return l.End()
+}
+
+// MarshalJSON encodes v with the fields resolved as the encoder of go-json does for Record.
+// The options of MarshalWithOption are not applied.
+func (v Record) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(func(b []byte) ([]byte, error) {
+		return jsongenAppendRecord(b, &v)
+	})
+}
+
+// UnmarshalJSON decodes data into v with the fields resolved as the decoder of go-json does for Record.
+// The options of UnmarshalWithOption are not applied.
+func (v *Record) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	if err := jsongenDecodeRecord(&l, v); err != nil {
+		return err
+	}
+	return l.End()
+}
+
+func jsongenAppendOrder(b []byte, v *Order) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, "\"id\":"...)
+	b = jsongen.AppendInt(b, int64(v.Base.ID))
+	b = append(b, ',')
+	if v.Base.CreatedBy != "" {
+		b = append(b, "\"created_by\":"...)
+		b = jsongen.AppendString(b, string(v.Base.CreatedBy))
+		b = append(b, ',')
+	}
+	if v.Audit != nil {
+		b = append(b, "\"UpdatedBy\":"...)
+		b = jsongen.AppendString(b, string(v.Audit.UpdatedBy))
+		b = append(b, ',')
+	}
+	if v.Audit != nil {
+		b = append(b, "\"revision\":"...)
+		b = append(b, '"')
+		b = jsongen.AppendUint(b, uint64(v.Audit.Revision))
+		b = append(b, '"')
+		b = append(b, ',')
+	}
+	b = append(b, "\"status\":"...)
+	b = jsongen.AppendString(b, string(v.Status))
+	b = append(b, ',')
+	if v.Level != 0 {
+		b = append(b, "\"level\":"...)
+		b = jsongen.AppendInt(b, int64(v.Level))
+		b = append(b, ',')
+	}
+	b = append(b, "\"paid\":"...)
+	b = jsongen.AppendBool(b, bool(v.Paid))
+	b = append(b, ',')
+	b = append(b, "\"total\":"...)
+	if b, err = jsongen.AppendFloat32(b, float32(v.Total)); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"quantity\":"...)
+	b = append(b, '"')
+	b = jsongen.AppendUint(b, uint64(v.Quantity))
+	b = append(b, '"')
+	b = append(b, ',')
+	if v.Note != "" {
+		b = append(b, "\"note\":"...)
+		b = jsongen.AppendString(b, string(v.Note))
+		b = append(b, ',')
+	}
+	if v.Quoted != "" {
+		b = append(b, "\"quoted\":"...)
+		b = jsongen.AppendQuotedString(b, string(v.Quoted))
+		b = append(b, ',')
+	}
+	b = append(b, "\"items\":"...)
+	if v.Items == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i0 := range v.Items {
+			if i0 > 0 {
+				b = append(b, ',')
+			}
+			if b, err = jsongenAppendItem(b, &v.Items[i0]); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	if len(v.Tags) != 0 {
+		b = append(b, "\"tags\":"...)
+		b = append(b, '[')
+		for i0 := range v.Tags {
+			if i0 > 0 {
+				b = append(b, ',')
+			}
+			b = jsongen.AppendString(b, string(v.Tags[i0]))
+		}
+		b = append(b, ']')
+		b = append(b, ',')
+	}
+	if v.Ref != nil {
+		b = append(b, "\"ref\":"...)
+		if b, err = jsongenAppendItem(b, v.Ref); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, "\"any\":"...)
+	if b, err = jsongen.AppendValue(b, v.Any); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"amount\":"...)
+	if b, err = jsongen.AppendValue(b, v.Amount); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"at\":"...)
+	if b, err = jsongen.AppendTime(b, v.At, ""); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"date\":"...)
+	if b, err = jsongen.AppendTime(b, v.Date, "2006-01-02"); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"ratio\":"...)
+	if b, err = jsongen.AppendFloat64Precision(b, float64(v.Ratio), 2); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(v.Raw) != 0 {
+		b = append(b, "\"raw\":"...)
+		if b, err = jsongen.AppendValue(b, v.Raw); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	return jsongen.EndObject(b), nil
+}
+
+func jsongenDecodeOrder(l *jsongen.Lexer, v *Order) error {
+	if l.Null() {
+		return nil
+	}
+	more, err := l.BeginObject()
+	if err != nil {
+		return err
+	}
+	for more {
+		key, err := l.Key()
+		if err != nil {
+			return err
+		}
+		var field int
+		switch string(key) {
+		case "UpdatedBy", "updatedby":
+			field = 1
+		case "amount":
+			field = 2
+		case "any":
+			field = 3
+		case "at":
+			field = 4
+		case "created_by":
+			field = 5
+		case "date":
+			field = 6
+		case "id":
+			field = 7
+		case "items":
+			field = 8
+		case "level":
+			field = 9
+		case "note":
+			field = 10
+		case "paid":
+			field = 11
+		case "quantity":
+			field = 12
+		case "quoted":
+			field = 13
+		case "ratio":
+			field = 14
+		case "raw":
+			field = 15
+		case "ref":
+			field = 16
+		case "revision":
+			field = 17
+		case "status":
+			field = 18
+		case "tags":
+			field = 19
+		case "total":
+			field = 20
+		}
+		switch field {
+		case 1:
+			if v.Audit == nil {
+				v.Audit = new(Audit)
+			}
+			if x, ok := l.String(); ok {
+				v.Audit.UpdatedBy = string(x)
+			} else if err := l.Decode(&v.Audit.UpdatedBy, "", "Audit", "UpdatedBy"); err != nil {
+				return err
+			}
+		case 2:
+			if err := l.Decode(&v.Amount, "", "Order", "Amount"); err != nil {
+				return err
+			}
+		case 3:
+			if err := l.Decode(&v.Any, "", "Order", "Any"); err != nil {
+				return err
+			}
+		case 4:
+			if x, ok := l.Time(""); ok {
+				v.At = x
+			} else if err := l.Decode(&v.At, "", "Order", "At"); err != nil {
+				return err
+			}
+		case 5:
+			if x, ok := l.String(); ok {
+				v.Base.CreatedBy = string(x)
+			} else if err := l.Decode(&v.Base.CreatedBy, "", "Base", "CreatedBy"); err != nil {
+				return err
+			}
+		case 6:
+			if x, ok := l.Time("2006-01-02"); ok {
+				v.Date = x
+			} else if err := l.Decode(&v.Date, "json:\",format=2006-01-02\"", "Order", "Date"); err != nil {
+				return err
+			}
+		case 7:
+			if x, ok := l.Int(64); ok {
+				v.Base.ID = int64(x)
+			} else if err := l.Decode(&v.Base.ID, "", "Base", "ID"); err != nil {
+				return err
+			}
+		case 8:
+			if l.Null() {
+				v.Items = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Items, "", "Order", "Items"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([]Item, 0, 2)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Items) {
+						s0 = append(s0, v.Items[i0])
+					} else {
+						s0 = append(s0, Item{})
+					}
+					if err := jsongenDecodeItem(l, &s0[i0]); err != nil {
+						return err
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Items = s0
+			}
+		case 9:
+			if x, ok := l.Int(8); ok {
+				v.Level = Level(x)
+			} else if err := l.Decode(&v.Level, "", "Order", "Level"); err != nil {
+				return err
+			}
+		case 10:
+			if x, ok := l.String(); ok {
+				v.Note = string(x)
+			} else if err := l.Decode(&v.Note, "", "Order", "Note"); err != nil {
+				return err
+			}
+		case 11:
+			if x, ok := l.Bool(); ok {
+				v.Paid = bool(x)
+			} else if err := l.Decode(&v.Paid, "", "Order", "Paid"); err != nil {
+				return err
+			}
+		case 12:
+			if x, ok := l.QuotedUint(0); ok {
+				v.Quantity = uint(x)
+			} else if err := l.Decode(&v.Quantity, "json:\",string\"", "Order", "Quantity"); err != nil {
+				return err
+			}
+		case 13:
+			if x, ok := l.QuotedString(); ok {
+				v.Quoted = string(x)
+			} else if err := l.Decode(&v.Quoted, "json:\",string\"", "Order", "Quoted"); err != nil {
+				return err
+			}
+		case 14:
+			if x, ok := l.Float(); ok {
+				v.Ratio = float64(x)
+			} else if err := l.Decode(&v.Ratio, "", "Order", "Ratio"); err != nil {
+				return err
+			}
+		case 15:
+			if err := l.Decode(&v.Raw, "", "Order", "Raw"); err != nil {
+				return err
+			}
+		case 16:
+			if l.Null() {
+				v.Ref = nil
+			} else {
+				if v.Ref == nil {
+					v.Ref = new(Item)
+				}
+				if err := jsongenDecodeItem(l, v.Ref); err != nil {
+					return err
+				}
+			}
+		case 17:
+			if v.Audit == nil {
+				v.Audit = new(Audit)
+			}
+			if x, ok := l.QuotedUint(16); ok {
+				v.Audit.Revision = uint16(x)
+			} else if err := l.Decode(&v.Audit.Revision, "json:\",string\"", "Audit", "Revision"); err != nil {
+				return err
+			}
+		case 18:
+			if x, ok := l.String(); ok {
+				v.Status = Status(x)
+			} else if err := l.Decode(&v.Status, "", "Order", "Status"); err != nil {
+				return err
+			}
+		case 19:
+			if l.Null() {
+				v.Tags = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Tags, "", "Order", "Tags"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([]string, 0, 4)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Tags) {
+						s0 = append(s0, v.Tags[i0])
+					} else {
+						s0 = append(s0, "")
+					}
+					if x, ok := l.String(); ok {
+						s0[i0] = string(x)
+					} else if err := l.Decode(&s0[i0], "", "Order", "Tags"); err != nil {
+						return err
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Tags = s0
+			}
+		case 20:
+			if x, ok := l.Float(); ok {
+				v.Total = float32(x)
+			} else if err := l.Decode(&v.Total, "", "Order", "Total"); err != nil {
+				return err
+			}
+		default:
+			if err := l.Skip(); err != nil {
+				return err
+			}
+		}
+		if more, err = l.NextMember(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsongenAppendConflict(b []byte, v *Conflict) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, "\"name\":"...)
+	b = jsongen.AppendString(b, string(v.Left.Name))
+	b = append(b, ',')
+	b = append(b, "\"Name\":"...)
+	b = jsongen.AppendString(b, string(v.Right.Name))
+	b = append(b, ',')
+	b = append(b, "\"Both\":"...)
+	b = jsongen.AppendString(b, string(v.Extra))
+	b = append(b, ',')
+	return jsongen.EndObject(b), nil
+}
+
+func jsongenDecodeConflict(l *jsongen.Lexer, v *Conflict) error {
+	if l.Null() {
+		return nil
+	}
+	more, err := l.BeginObject()
+	if err != nil {
+		return err
+	}
+	for more {
+		key, err := l.Key()
+		if err != nil {
+			return err
+		}
+		var field int
+		switch string(key) {
+		case "Both", "both":
+			field = 1
+		case "Name":
+			field = 2
+		case "name":
+			field = 3
+		}
+		switch field {
+		case 1:
+			if x, ok := l.String(); ok {
+				v.Extra = string(x)
+			} else if err := l.Decode(&v.Extra, "", "Conflict", "Extra"); err != nil {
+				return err
+			}
+		case 2:
+			if x, ok := l.String(); ok {
+				v.Right.Name = string(x)
+			} else if err := l.Decode(&v.Right.Name, "", "Right", "Name"); err != nil {
+				return err
+			}
+		case 3:
+			if x, ok := l.String(); ok {
+				v.Left.Name = string(x)
+			} else if err := l.Decode(&v.Left.Name, "", "Left", "Name"); err != nil {
+				return err
+			}
+		default:
+			if err := l.Skip(); err != nil {
+				return err
+			}
+		}
+		if more, err = l.NextMember(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsongenAppendNested(b []byte, v *Nested) ([]byte, error) {
+	b = append(b, '{')
+	if v.Middle.inner != nil {
+		b = append(b, "\"deep\":"...)
+		b = jsongen.AppendString(b, string(v.Middle.inner.Deep))
+		b = append(b, ',')
+	}
+	if v.Middle.inner != nil {
+		b = append(b, "\"name\":"...)
+		b = jsongen.AppendString(b, string(v.Middle.inner.Name))
+		b = append(b, ',')
+	}
+	b = append(b, "\"kind\":"...)
+	b = jsongen.AppendString(b, string(v.Middle.Kind))
+	b = append(b, ',')
+	b = append(b, "\"NAME\":"...)
+	b = jsongen.AppendString(b, string(v.Name))
+	b = append(b, ',')
+	b = append(b, "\"label\":"...)
+	b = jsongen.AppendString(b, string(v.Label))
+	b = append(b, ',')
+	return jsongen.EndObject(b), nil
+}
+
+func jsongenDecodeNested(l *jsongen.Lexer, v *Nested) error {
+	if l.Null() {
+		return nil
+	}
+	more, err := l.BeginObject()
+	if err != nil {
+		return err
+	}
+	for more {
+		key, err := l.Key()
+		if err != nil {
+			return err
+		}
+		var field int
+		switch string(key) {
+		case "NAME":
+			field = 1
+		case "deep":
+			field = 2
+		case "kind":
+			field = 3
+		case "label":
+			field = 4
+		case "name":
+			field = 5
+		}
+		switch field {
+		case 1:
+			if x, ok := l.String(); ok {
+				v.Name = string(x)
+			} else if err := l.Decode(&v.Name, "", "Nested", "Name"); err != nil {
+				return err
+			}
+		case 2:
+			if v.Middle.inner == nil {
+				v.Middle.inner = new(inner)
+			}
+			if x, ok := l.String(); ok {
+				v.Middle.inner.Deep = string(x)
+			} else if err := l.Decode(&v.Middle.inner.Deep, "", "inner", "Deep"); err != nil {
+				return err
+			}
+		case 3:
+			if x, ok := l.String(); ok {
+				v.Middle.Kind = string(x)
+			} else if err := l.Decode(&v.Middle.Kind, "", "Middle", "Kind"); err != nil {
+				return err
+			}
+		case 4:
+			if x, ok := l.String(); ok {
+				v.Label = string(x)
+			} else if err := l.Decode(&v.Label, "", "Nested", "Label"); err != nil {
+				return err
+			}
+		case 5:
+			if v.Middle.inner == nil {
+				v.Middle.inner = new(inner)
+			}
+			if x, ok := l.String(); ok {
+				v.Middle.inner.Name = string(x)
+			} else if err := l.Decode(&v.Middle.inner.Name, "", "inner", "Name"); err != nil {
+				return err
+			}
+		default:
+			if err := l.Skip(); err != nil {
+				return err
+			}
+		}
+		if more, err = l.NextMember(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsongenAppendRecord(b []byte, v *Record) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, "\"data\":"...)
+	if v.Data == nil {
+		b = append(b, "null"...)
+	} else {
+		b = jsongen.AppendBytes(b, v.Data)
+	}
+	b = append(b, ',')
+	b = append(b, "\"blob\":"...)
+	if v.Blob == nil {
+		b = append(b, "\"\""...)
+	} else {
+		b = jsongen.AppendBytes(b, v.Blob)
+	}
+	b = append(b, ',')
+	b = append(b, "\"score\":"...)
+	if v.Score == nil {
+		b = append(b, "null"...)
+	} else {
+		if b, err = jsongen.AppendFloat64Precision(b, float64(*v.Score), 1); err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, ',')
+	b = append(b, "\"count\":"...)
+	if v.Count == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '"')
+		b = jsongen.AppendInt(b, int64(*v.Count))
+		b = append(b, '"')
+	}
+	b = append(b, ',')
+	b = append(b, "\"times\":"...)
+	if v.Times == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i0 := range v.Times {
+			if i0 > 0 {
+				b = append(b, ',')
+			}
+			if b, err = jsongen.AppendTime(b, v.Times[i0], "unixmilli"); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	if v.At != nil {
+		b = append(b, "\"at\":"...)
+		if b, err = jsongen.AppendTime(b, *v.At, ""); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, "\"matrix\":"...)
+	if v.Matrix == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i0 := range v.Matrix {
+			if i0 > 0 {
+				b = append(b, ',')
+			}
+			if v.Matrix[i0] == nil {
+				b = append(b, "null"...)
+			} else {
+				b = append(b, '[')
+				for i1 := range v.Matrix[i0] {
+					if i1 > 0 {
+						b = append(b, ',')
+					}
+					b = jsongen.AppendInt(b, int64(v.Matrix[i0][i1]))
+				}
+				b = append(b, ']')
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, "\"points\":"...)
+	if v.Points == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i0 := range v.Points {
+			if i0 > 0 {
+				b = append(b, ',')
+			}
+			if v.Points[i0] == nil {
+				b = append(b, "null"...)
+			} else {
+				if b, err = jsongenAppendPoint(b, v.Points[i0]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, "\"groups\":"...)
+	if b, err = jsongen.AppendField(b, &v.Groups, "json:\"groups,nilasempty\""); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"names\":"...)
+	if v.Names == nil {
+		b = append(b, "[]"...)
+	} else {
+		b = append(b, '[')
+		for i0 := range v.Names {
+			if i0 > 0 {
+				b = append(b, ',')
+			}
+			b = jsongen.AppendString(b, string(v.Names[i0]))
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, "\"pair\":"...)
+	if b, err = jsongen.AppendValue(b, v.Pair); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"temps\":"...)
+	if v.Temps == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i0 := range v.Temps {
+			if i0 > 0 {
+				b = append(b, ',')
+			}
+			if b, err = jsongen.AppendValue(b, &v.Temps[i0]); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	if v.Next != nil {
+		b = append(b, "\"next\":"...)
+		if b, err = jsongenAppendRecord(b, v.Next); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	return jsongen.EndObject(b), nil
+}
+
+func jsongenDecodeRecord(l *jsongen.Lexer, v *Record) error {
+	if l.Null() {
+		return nil
+	}
+	more, err := l.BeginObject()
+	if err != nil {
+		return err
+	}
+	for more {
+		key, err := l.Key()
+		if err != nil {
+			return err
+		}
+		var field int
+		switch string(key) {
+		case "at":
+			field = 1
+		case "blob":
+			field = 2
+		case "count":
+			field = 3
+		case "data":
+			field = 4
+		case "groups":
+			field = 5
+		case "matrix":
+			field = 6
+		case "names":
+			field = 7
+		case "next":
+			field = 8
+		case "pair":
+			field = 9
+		case "points":
+			field = 10
+		case "score":
+			field = 11
+		case "temps":
+			field = 12
+		case "times":
+			field = 13
+		default:
+			switch string(jsongen.ToLowerASCII(key)) {
+			case "at":
+				field = 1
+			case "blob":
+				field = 2
+			case "count":
+				field = 3
+			case "data":
+				field = 4
+			case "groups":
+				field = 5
+			case "matrix":
+				field = 6
+			case "names":
+				field = 7
+			case "next":
+				field = 8
+			case "pair":
+				field = 9
+			case "points":
+				field = 10
+			case "score":
+				field = 11
+			case "temps":
+				field = 12
+			case "times":
+				field = 13
+			}
+		}
+		switch field {
+		case 1:
+			if l.Null() {
+				v.At = nil
+			} else {
+				if v.At == nil {
+					v.At = new(time.Time)
+				}
+				if x, ok := l.Time(""); ok {
+					*v.At = x
+				} else if err := l.Decode(v.At, "", "Record", "At"); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if x, ok := l.Bytes(); ok {
+				v.Blob = []byte(x)
+			} else if err := l.Decode(&v.Blob, "", "Record", "Blob"); err != nil {
+				return err
+			}
+		case 3:
+			if l.Null() {
+				v.Count = nil
+			} else if x, ok := l.QuotedInt(0); ok {
+				if v.Count == nil {
+					v.Count = new(int)
+				}
+				*v.Count = int(x)
+			} else if err := l.Decode(&v.Count, "json:\",string\"", "Record", "Count"); err != nil {
+				return err
+			}
+		case 4:
+			if x, ok := l.Bytes(); ok {
+				v.Data = []byte(x)
+			} else if err := l.Decode(&v.Data, "", "Record", "Data"); err != nil {
+				return err
+			}
+		case 5:
+			if l.Null() {
+				v.Groups = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Groups, "", "Record", "Groups"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([][]Point, 0, 2)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Groups) {
+						s0 = append(s0, v.Groups[i0])
+					} else {
+						s0 = append(s0, nil)
+					}
+					if l.Null() {
+						s0[i0] = nil
+					} else if more1, ok := l.BeginArray(); !ok {
+						if err := l.Decode(&s0[i0], "", "Record", "Groups"); err != nil {
+							return err
+						}
+					} else {
+						s1 := make([]Point, 0, 4)
+						for i1 := 0; more1; i1++ {
+							if i1 < len(s0[i0]) {
+								s1 = append(s1, s0[i0][i1])
+							} else {
+								s1 = append(s1, Point{})
+							}
+							if err := jsongenDecodePoint(l, &s1[i1]); err != nil {
+								return err
+							}
+							if more1, err = l.NextElement(); err != nil {
+								return err
+							}
+						}
+						s0[i0] = s1
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Groups = s0
+			}
+		case 6:
+			if l.Null() {
+				v.Matrix = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Matrix, "", "Record", "Matrix"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([][]int, 0, 2)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Matrix) {
+						s0 = append(s0, v.Matrix[i0])
+					} else {
+						s0 = append(s0, nil)
+					}
+					if l.Null() {
+						s0[i0] = nil
+					} else if more1, ok := l.BeginArray(); !ok {
+						if err := l.Decode(&s0[i0], "", "Record", "Matrix"); err != nil {
+							return err
+						}
+					} else {
+						s1 := make([]int, 0, 8)
+						for i1 := 0; more1; i1++ {
+							if i1 < len(s0[i0]) {
+								s1 = append(s1, s0[i0][i1])
+							} else {
+								s1 = append(s1, 0)
+							}
+							if x, ok := l.Int(0); ok {
+								s1[i1] = int(x)
+							} else if err := l.Decode(&s1[i1], "", "Record", "Matrix"); err != nil {
+								return err
+							}
+							if more1, err = l.NextElement(); err != nil {
+								return err
+							}
+						}
+						s0[i0] = s1
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Matrix = s0
+			}
+		case 7:
+			if l.Null() {
+				v.Names = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Names, "", "Record", "Names"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([]string, 0, 4)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Names) {
+						s0 = append(s0, v.Names[i0])
+					} else {
+						s0 = append(s0, "")
+					}
+					if x, ok := l.String(); ok {
+						s0[i0] = string(x)
+					} else if err := l.Decode(&s0[i0], "", "Record", "Names"); err != nil {
+						return err
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Names = s0
+			}
+		case 8:
+			if l.Null() {
+				v.Next = nil
+			} else {
+				if v.Next == nil {
+					v.Next = new(Record)
+				}
+				if err := jsongenDecodeRecord(l, v.Next); err != nil {
+					return err
+				}
+			}
+		case 9:
+			if err := l.Decode(&v.Pair, "", "Record", "Pair"); err != nil {
+				return err
+			}
+		case 10:
+			if l.Null() {
+				v.Points = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Points, "", "Record", "Points"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([]*Point, 0, 8)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Points) {
+						s0 = append(s0, v.Points[i0])
+					} else {
+						s0 = append(s0, nil)
+					}
+					if l.Null() {
+						s0[i0] = nil
+					} else {
+						if s0[i0] == nil {
+							s0[i0] = new(Point)
+						}
+						if err := jsongenDecodePoint(l, s0[i0]); err != nil {
+							return err
+						}
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Points = s0
+			}
+		case 11:
+			if l.Null() {
+				v.Score = nil
+			} else {
+				if v.Score == nil {
+					v.Score = new(float64)
+				}
+				if x, ok := l.Float(); ok {
+					*v.Score = float64(x)
+				} else if err := l.Decode(v.Score, "", "Record", "Score"); err != nil {
+					return err
+				}
+			}
+		case 12:
+			if l.Null() {
+				v.Temps = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Temps, "", "Record", "Temps"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([]Celsius, 0, 8)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Temps) {
+						s0 = append(s0, v.Temps[i0])
+					} else {
+						s0 = append(s0, 0)
+					}
+					if err := l.Decode(&s0[i0], "", "Record", "Temps"); err != nil {
+						return err
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Temps = s0
+			}
+		case 13:
+			if l.Null() {
+				v.Times = nil
+			} else if more0, ok := l.BeginArray(); !ok {
+				if err := l.Decode(&v.Times, "json:\",format=unixmilli\"", "Record", "Times"); err != nil {
+					return err
+				}
+			} else {
+				s0 := make([]time.Time, 0, 2)
+				for i0 := 0; more0; i0++ {
+					if i0 < len(v.Times) {
+						s0 = append(s0, v.Times[i0])
+					} else {
+						s0 = append(s0, time.Time{})
+					}
+					if x, ok := l.Time("unixmilli"); ok {
+						s0[i0] = x
+					} else if err := l.Decode(&s0[i0], "json:\",format=unixmilli\"", "Record", "Times"); err != nil {
+						return err
+					}
+					if more0, err = l.NextElement(); err != nil {
+						return err
+					}
+				}
+				v.Times = s0
+			}
+		default:
+			if err := l.Skip(); err != nil {
+				return err
+			}
+		}
+		if more, err = l.NextMember(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsongenAppendItem(b []byte, v *Item) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, "\"name\":"...)
+	b = jsongen.AppendString(b, string(v.Name))
+	b = append(b, ',')
+	b = append(b, "\"price\":"...)
+	if b, err = jsongen.AppendFloat64(b, float64(v.Price)); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	return jsongen.EndObject(b), nil
+}
+
+func jsongenDecodeItem(l *jsongen.Lexer, v *Item) error {
+	if l.Null() {
+		return nil
+	}
+	more, err := l.BeginObject()
+	if err != nil {
+		return err
+	}
+	for more {
+		key, err := l.Key()
+		if err != nil {
+			return err
+		}
+		var field int
+		switch string(key) {
+		case "name":
+			field = 1
+		case "price":
+			field = 2
+		default:
+			switch string(jsongen.ToLowerASCII(key)) {
+			case "name":
+				field = 1
+			case "price":
+				field = 2
+			}
+		}
+		switch field {
+		case 1:
+			if x, ok := l.String(); ok {
+				v.Name = string(x)
+			} else if err := l.Decode(&v.Name, "", "Item", "Name"); err != nil {
+				return err
+			}
+		case 2:
+			if x, ok := l.Float(); ok {
+				v.Price = float64(x)
+			} else if err := l.Decode(&v.Price, "", "Item", "Price"); err != nil {
+				return err
+			}
+		default:
+			if err := l.Skip(); err != nil {
+				return err
+			}
+		}
+		if more, err = l.NextMember(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsongenAppendPoint(b []byte, v *Point) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, "\"X\":"...)
+	b = jsongen.AppendInt(b, int64(v.X))
+	b = append(b, ',')
+	b = append(b, "\"Y\":"...)
+	b = jsongen.AppendInt(b, int64(v.Y))
+	b = append(b, ',')
+	return jsongen.EndObject(b), nil
+}
+
+func jsongenDecodePoint(l *jsongen.Lexer, v *Point) error {
+	if l.Null() {
+		return nil
+	}
+	more, err := l.BeginObject()
+	if err != nil {
+		return err
+	}
+	for more {
+		key, err := l.Key()
+		if err != nil {
+			return err
+		}
+		var field int
+		switch string(key) {
+		case "X", "x":
+			field = 1
+		case "Y", "y":
+			field = 2
+		default:
+			switch string(jsongen.ToLowerASCII(key)) {
+			case "x":
+				field = 1
+			case "y":
+				field = 2
+			}
+		}
+		switch field {
+		case 1:
+			if x, ok := l.Int(0); ok {
+				v.X = int(x)
+			} else if err := l.Decode(&v.X, "", "Point", "X"); err != nil {
+				return err
+			}
+		case 2:
+			if x, ok := l.Int(0); ok {
+				v.Y = int(x)
+			} else if err := l.Decode(&v.Y, "", "Point", "Y"); err != nil {
+				return err
+			}
+		default:
+			if err := l.Skip(); err != nil {
+				return err
+			}
+		}
+		if more, err = l.NextMember(); err != nil {
+			return err
+		}
+	}
+	return nil
+}